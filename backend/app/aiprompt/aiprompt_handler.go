@@ -0,0 +1,67 @@
+package aiprompt
+
+import (
+	"net/http"
+
+	"joosum-backend/pkg/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AIPromptHandler struct {
+	aiPromptUsecase AIPromptUsecase
+}
+
+// GetAIPrompts
+// @Tags AI
+// @Summary AI 프롬프트 버전 조회
+// @Description 프롬프트의 모든 버전(내장, 설정 디렉터리, DB)과 현재 적용 중인 버전별 사용자 비율을 반환합니다.
+// @Param name query string false "프롬프트 이름 (기본 ai_tags)"
+// @Success 200 {object} aiprompt.AIPromptListRes
+// @Failure 500 {object} util.APIError "프롬프트를 불러오는 과정에서 오류가 발생한 경우 반환합니다."
+// @Security InternalApiKeyAuth
+// @Router /ai-prompts [get]
+func (h AIPromptHandler) GetAIPrompts(c *gin.Context) {
+	name := c.DefaultQuery("name", NameAITags)
+
+	res, err := h.aiPromptUsecase.ListPrompts(name)
+	if err != nil {
+		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// SaveAIPrompt
+// @Tags AI
+// @Summary AI 프롬프트 버전 저장
+// @Description 프롬프트 템플릿을 DB 에 저장합니다. 같은 이름과 버전이 있으면 덮어씁니다.
+// @Description 저장한 버전은 설정(aiTagPromptVersion, aiTagPromptSplit)에서 골라야 사용되며, 서버마다 1분 안에 반영됩니다.
+// @Accept json
+// @Produce json
+// @Param request body aiprompt.AIPrompt true "프롬프트 템플릿 (system, user 는 text/template 형식)"
+// @Success 200 {object} aiprompt.AIPrompt
+// @Failure 400 {object} util.APIError "이름, 버전, 템플릿이 없거나 템플릿 문법이 올바르지 않을 때 반환합니다."
+// @Failure 500 {object} util.APIError "저장 과정에서 오류가 발생한 경우 반환합니다."
+// @Security InternalApiKeyAuth
+// @Router /ai-prompts [put]
+func (h AIPromptHandler) SaveAIPrompt(c *gin.Context) {
+	var req AIPrompt
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.SendError(c, http.StatusBadRequest, util.CodeInvalidRequestBody)
+		return
+	}
+
+	res, err := h.aiPromptUsecase.SavePrompt(req)
+	if err != nil {
+		if err == util.ErrInvalidAIPrompt {
+			util.SendError(c, http.StatusBadRequest, util.CodeInvalidAIPrompt)
+			return
+		}
+		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
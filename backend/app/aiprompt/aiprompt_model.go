@@ -0,0 +1,84 @@
+package aiprompt
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"joosum-backend/pkg/db"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 프롬프트 이름 (기능별)
+const (
+	NameAITags = "ai_tags"
+)
+
+// 프롬프트를 불러온 곳
+const (
+	SourceBuiltin = "builtin"
+	SourceConfig  = "config"
+	SourceDB      = "db"
+)
+
+type AIPromptModel struct{}
+
+// AIPrompt 는 버전이 있는 프롬프트 템플릿입니다. (aiPrompts 컬렉션)
+// System, User 는 text/template 형식이며 기능마다 정해진 데이터로 렌더링합니다.
+type AIPrompt struct {
+	Name        string `bson:"name" json:"name" example:"ai_tags"`
+	Version     string `bson:"version" json:"version" example:"v3"`
+	Description string `bson:"description,omitempty" json:"description,omitempty"`
+	System      string `bson:"system" json:"system"`
+	User        string `bson:"user" json:"user"`
+	// 0 이면 기능의 기본값을 사용합니다.
+	Temperature float32   `bson:"temperature,omitempty" json:"temperature,omitempty" example:"0.3"`
+	MaxTokens   int       `bson:"max_tokens,omitempty" json:"maxTokens,omitempty" example:"200"`
+	Source      string    `bson:"-" json:"source" example:"db"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updatedAt"`
+}
+
+// Hash 는 템플릿 내용의 해시입니다. 같은 버전의 템플릿을 고쳤을 때 캐시를 구분하는 데 사용합니다.
+func (p AIPrompt) Hash() string {
+	sum := sha256.Sum256([]byte(p.System + "\x00" + p.User))
+	return hex.EncodeToString(sum[:8])
+}
+
+type AIPromptListRes struct {
+	Name string `json:"name" example:"ai_tags"`
+	// 버전별 사용자 비율 (%). 설정된 버전이 하나면 100 입니다.
+	Split   map[string]int `json:"split"`
+	Prompts []AIPrompt     `json:"prompts"`
+}
+
+func (AIPromptModel) GetPromptsByName(name string) ([]AIPrompt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cur, err := db.AIPromptCollection.Find(ctx, bson.M{"name": name})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	prompts := []AIPrompt{}
+	if err := cur.All(ctx, &prompts); err != nil {
+		return nil, err
+	}
+
+	return prompts, nil
+}
+
+func (AIPromptModel) UpsertPrompt(prompt AIPrompt) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"name": prompt.Name, "version": prompt.Version}
+	opts := options.Replace().SetUpsert(true)
+	_, err := db.AIPromptCollection.ReplaceOne(ctx, filter, prompt, opts)
+
+	return err
+}
@@ -0,0 +1,315 @@
+package aiprompt
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"joosum-backend/pkg/config"
+	"joosum-backend/pkg/util"
+)
+
+// 내장 프롬프트. prompts/<이름>/<버전>/{system,user}.tmpl
+//
+//go:embed prompts
+var builtinPrompts embed.FS
+
+// 설정이 없을 때 사용하는 기능별 프롬프트 버전
+var defaultVersions = map[string]string{
+	NameAITags: "v2",
+}
+
+// 기능별 설정 키 접두사. <접두사>Version 으로 버전 하나를, <접두사>Split 으로 A/B 비율("v2:90,v3:10")을 고릅니다.
+var configPrefixes = map[string]string{
+	NameAITags: "aiTagPrompt",
+}
+
+// DB/설정 디렉터리의 프롬프트는 1분 동안 메모리에 두고 사용합니다.
+const promptCacheTTL = time.Minute
+
+var promptCache = struct {
+	sync.Mutex
+	prompts  map[string]map[string]AIPrompt
+	loadedAt map[string]time.Time
+}{
+	prompts:  map[string]map[string]AIPrompt{},
+	loadedAt: map[string]time.Time{},
+}
+
+type AIPromptUsecase struct {
+	aiPromptModel AIPromptModel
+}
+
+// GetPrompts 는 name 프롬프트의 모든 버전을 반환합니다.
+// 같은 버전이 여러 곳에 있으면 내장 < 설정 디렉터리(aiPromptDir) < DB 순으로 덮어씁니다.
+// 캐시가 만료되면 잠금 없이 다시 읽으므로, 읽는 동안에도 다른 요청은 기다리지 않습니다.
+func (u AIPromptUsecase) GetPrompts(name string) (map[string]AIPrompt, error) {
+	promptCache.Lock()
+	loadedAt, ok := promptCache.loadedAt[name]
+	cached := promptCache.prompts[name]
+	promptCache.Unlock()
+
+	if ok && time.Since(loadedAt) < promptCacheTTL {
+		return cached, nil
+	}
+
+	prompts, err := LoadPromptFiles(name, config.GetEnvConfig("aiPromptDir"))
+	if err != nil {
+		return nil, err
+	}
+
+	dbPrompts, err := u.aiPromptModel.GetPromptsByName(name)
+	if err != nil {
+		// DB 를 읽지 못해도 내장/설정 프롬프트로 계속 동작
+		log.Printf("[AI 프롬프트] DB 프롬프트 로드 실패 (name=%s): %v", name, err)
+	}
+	for _, prompt := range dbPrompts {
+		prompt.Source = SourceDB
+		prompts[prompt.Version] = prompt
+	}
+
+	promptCache.Lock()
+	promptCache.prompts[name] = prompts
+	promptCache.loadedAt[name] = time.Now()
+	promptCache.Unlock()
+
+	return prompts, nil
+}
+
+// SelectPrompt 는 사용자에게 적용할 프롬프트 버전을 고릅니다.
+// A/B 비율이 설정되어 있으면 사용자 아이디로 버전을 나누므로, 같은 사용자는 항상 같은 버전을 받습니다.
+func (u AIPromptUsecase) SelectPrompt(name string, userId string) (*AIPrompt, error) {
+	prompts, err := u.GetPrompts(name)
+	if err != nil {
+		return nil, err
+	}
+
+	version := pickVersion(getSplit(name), name+":"+userId)
+	prompt, ok := prompts[version]
+	if !ok {
+		log.Printf("[AI 프롬프트] 설정된 버전이 없어 기본 버전 사용 (name=%s, version=%s)", name, version)
+		prompt, ok = prompts[defaultVersions[name]]
+		if !ok {
+			return nil, fmt.Errorf("prompt not found: %s", name)
+		}
+	}
+
+	return &prompt, nil
+}
+
+// ListPrompts 는 name 프롬프트의 버전 목록과 현재 A/B 비율을 반환합니다.
+func (u AIPromptUsecase) ListPrompts(name string) (*AIPromptListRes, error) {
+	prompts, err := u.GetPrompts(name)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &AIPromptListRes{Name: name, Split: getSplit(name), Prompts: []AIPrompt{}}
+	for _, prompt := range prompts {
+		res.Prompts = append(res.Prompts, prompt)
+	}
+	sort.Slice(res.Prompts, func(i, j int) bool {
+		return res.Prompts[i].Version < res.Prompts[j].Version
+	})
+
+	return res, nil
+}
+
+// SavePrompt 는 템플릿을 검사한 뒤 DB 에 저장합니다. 저장한 버전은 설정에서 고르거나 A/B 비율에 넣어야 사용됩니다.
+func (u AIPromptUsecase) SavePrompt(prompt AIPrompt) (*AIPrompt, error) {
+	if _, ok := defaultVersions[prompt.Name]; !ok || prompt.Version == "" || prompt.System == "" || prompt.User == "" {
+		return nil, util.ErrInvalidAIPrompt
+	}
+	if _, err := parseTemplate(prompt.Name+".system", prompt.System); err != nil {
+		log.Printf("[AI 프롬프트] 시스템 템플릿 파싱 실패 (name=%s, version=%s): %v", prompt.Name, prompt.Version, err)
+		return nil, util.ErrInvalidAIPrompt
+	}
+	if _, err := parseTemplate(prompt.Name+".user", prompt.User); err != nil {
+		log.Printf("[AI 프롬프트] 사용자 템플릿 파싱 실패 (name=%s, version=%s): %v", prompt.Name, prompt.Version, err)
+		return nil, util.ErrInvalidAIPrompt
+	}
+
+	prompt.UpdatedAt = time.Now()
+	if err := u.aiPromptModel.UpsertPrompt(prompt); err != nil {
+		return nil, err
+	}
+
+	promptCache.Lock()
+	delete(promptCache.loadedAt, prompt.Name)
+	promptCache.Unlock()
+
+	prompt.Source = SourceDB
+	return &prompt, nil
+}
+
+// Render 는 템플릿에 data 를 넣어 시스템/사용자 프롬프트를 만듭니다.
+func (p AIPrompt) Render(data interface{}) (string, string, error) {
+	system, err := renderTemplate(p.Name+".system", p.System, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	user, err := renderTemplate(p.Name+".user", p.User, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	return system, user, nil
+}
+
+// LoadPromptFiles 는 DB 없이 내장 프롬프트와 dir(비어 있으면 생략)의 프롬프트를 읽습니다.
+// dir 에 같은 버전이 있으면 내장 프롬프트를 덮어씁니다.
+func LoadPromptFiles(name string, dir string) (map[string]AIPrompt, error) {
+	prompts, err := loadDirPrompts(builtinPrompts, name, SourceBuiltin)
+	if err != nil {
+		return nil, err
+	}
+
+	if dir != "" {
+		dirPrompts, err := loadDirPrompts(os.DirFS(dir), name, SourceConfig)
+		if err != nil {
+			log.Printf("[AI 프롬프트] 설정 디렉터리 프롬프트 로드 실패 (dir=%s, name=%s): %v", dir, name, err)
+		}
+		for version, prompt := range dirPrompts {
+			prompts[version] = prompt
+		}
+	}
+
+	return prompts, nil
+}
+
+// DefaultVersion 은 설정이 없을 때 사용하는 name 프롬프트의 버전입니다.
+func DefaultVersion(name string) string {
+	return defaultVersions[name]
+}
+
+// loadDirPrompts 는 <name>/<버전>/{system,user}.tmpl 구조의 파일에서 프롬프트를 읽습니다.
+func loadDirPrompts(fsys fs.FS, name string, source string) (map[string]AIPrompt, error) {
+	prompts := map[string]AIPrompt{}
+
+	root := name
+	if source == SourceBuiltin {
+		root = path.Join("prompts", name)
+	}
+
+	entries, err := fs.ReadDir(fsys, root)
+	if err != nil {
+		if source == SourceConfig && errors.Is(err, fs.ErrNotExist) {
+			return prompts, nil
+		}
+		return prompts, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		dir := path.Join(root, entry.Name())
+		system, err := fs.ReadFile(fsys, path.Join(dir, "system.tmpl"))
+		if err != nil {
+			return prompts, err
+		}
+		user, err := fs.ReadFile(fsys, path.Join(dir, "user.tmpl"))
+		if err != nil {
+			return prompts, err
+		}
+
+		prompts[entry.Name()] = AIPrompt{
+			Name:    name,
+			Version: entry.Name(),
+			System:  strings.TrimSpace(string(system)),
+			User:    strings.TrimSpace(string(user)),
+			Source:  source,
+		}
+	}
+
+	return prompts, nil
+}
+
+// getSplit 은 설정에서 버전별 사용자 비율을 읽습니다.
+// <접두사>Split("v2:90,v3:10") 이 있으면 그 비율을, 없으면 <접두사>Version 또는 기본 버전 하나를 100 으로 반환합니다.
+func getSplit(name string) map[string]int {
+	prefix := configPrefixes[name]
+
+	if value := config.GetEnvConfig(prefix + "Split"); value != "" {
+		split := map[string]int{}
+		for _, part := range strings.Split(value, ",") {
+			version, weight, ok := strings.Cut(strings.TrimSpace(part), ":")
+			n, err := strconv.Atoi(strings.TrimSpace(weight))
+			if !ok || version == "" || err != nil || n < 0 {
+				log.Printf("[AI 프롬프트] 잘못된 %sSplit 설정(%s), 무시", prefix, value)
+				split = nil
+				break
+			}
+			split[strings.TrimSpace(version)] += n
+		}
+		if len(split) > 0 {
+			return split
+		}
+	}
+
+	version := config.GetEnvConfig(prefix + "Version")
+	if version == "" {
+		version = defaultVersions[name]
+	}
+
+	return map[string]int{version: 100}
+}
+
+// pickVersion 은 key 의 해시로 비율에 따라 버전을 고릅니다.
+func pickVersion(split map[string]int, key string) string {
+	versions := make([]string, 0, len(split))
+	total := 0
+	for version, weight := range split {
+		versions = append(versions, version)
+		total += weight
+	}
+	sort.Strings(versions)
+
+	if total == 0 {
+		return versions[0]
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	bucket := int(h.Sum32() % uint32(total))
+
+	for _, version := range versions {
+		bucket -= split[version]
+		if bucket < 0 {
+			return version
+		}
+	}
+
+	return versions[len(versions)-1]
+}
+
+func parseTemplate(name string, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(text)
+}
+
+func renderTemplate(name string, text string, data interface{}) (string, error) {
+	tmpl, err := parseTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(sb.String()), nil
+}
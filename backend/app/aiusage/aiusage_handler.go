@@ -0,0 +1,48 @@
+package aiusage
+
+import (
+	"net/http"
+	"strconv"
+
+	"joosum-backend/pkg/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 사용량 조회 기간 기본값/최대값 (일)
+const (
+	defaultUsageDays = 30
+	maxUsageDays     = 365
+)
+
+type AIUsageHandler struct {
+	aiUsageUsecase AIUsageUsecase
+}
+
+// GetAIUsage
+// @Tags AI
+// @Summary AI 사용량 조회
+// @Description 최근 days 일 동안의 AI 호출 수, 토큰 사용량, 예상 비용(USD)을 기능별로 집계합니다.
+// @Description userId 를 넣으면 해당 사용자의 사용량과 오늘/이번 달 한도를, 비우면 전체 사용량과 비용이 큰 사용자 20명을 반환합니다.
+// @Param userId query string false "사용자 아이디"
+// @Param days query int false "조회 기간 (기본 30일, 최대 365일)"
+// @Success 200 {object} aiusage.AIUsageSummaryRes
+// @Failure 500 {object} util.APIError "집계 과정에서 오류가 발생한 경우 반환합니다."
+// @Security InternalApiKeyAuth
+// @Router /ai-usage [get]
+func (h AIUsageHandler) GetAIUsage(c *gin.Context) {
+	days, err := strconv.Atoi(c.Query("days"))
+	if err != nil || days <= 0 {
+		days = defaultUsageDays
+	} else if days > maxUsageDays {
+		days = maxUsageDays
+	}
+
+	res, err := h.aiUsageUsecase.GetUsageSummary(c.Query("userId"), days)
+	if err != nil {
+		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,141 @@
+package aiusage
+
+import (
+	"context"
+	"time"
+
+	"joosum-backend/pkg/db"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AI 기능 구분
+const (
+	FeatureTags               = "ai_tags"
+	FeatureSummary            = "summary"
+	FeatureLinkBookSuggestion = "link_book_suggestion"
+	FeatureAsk                = "ask"
+	FeatureEmbedding          = "embedding"
+)
+
+type AIUsageModel struct{}
+
+// AIUsage 는 AI 호출 한 번의 사용량입니다. (aiUsages 컬렉션)
+type AIUsage struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty"`
+	UserId           string             `bson:"user_id"`
+	Feature          string             `bson:"feature"`
+	Provider         string             `bson:"provider"`
+	Model            string             `bson:"model"`
+	PromptTokens     int                `bson:"prompt_tokens"`
+	CompletionTokens int                `bson:"completion_tokens"`
+	CostUSD          float64            `bson:"cost_usd"`
+	// 버전이 있는 프롬프트(aiprompt)로 호출한 경우 프롬프트 버전. A/B 비교에 사용합니다.
+	PromptVersion string `bson:"prompt_version,omitempty"`
+	// 캐시된 결과를 반환한 경우. 한도 계산에서 제외합니다.
+	Cached    bool      `bson:"cached"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type AIQuota struct {
+	DailyLimit   int64 `json:"dailyLimit" example:"100"`
+	DailyUsed    int64 `json:"dailyUsed" example:"12"`
+	MonthlyLimit int64 `json:"monthlyLimit" example:"1000"`
+	MonthlyUsed  int64 `json:"monthlyUsed" example:"240"`
+}
+
+type AIUsageTotal struct {
+	Key              string  `bson:"_id" json:"key" example:"ai_tags"`
+	Requests         int64   `bson:"requests" json:"requests"`
+	CachedRequests   int64   `bson:"cached_requests" json:"cachedRequests"`
+	PromptTokens     int64   `bson:"prompt_tokens" json:"promptTokens"`
+	CompletionTokens int64   `bson:"completion_tokens" json:"completionTokens"`
+	CostUSD          float64 `bson:"cost_usd" json:"costUSD"`
+}
+
+type AIUsageSummaryRes struct {
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	UserId string    `json:"userId,omitempty"`
+	// userId 로 조회한 경우에만 채워집니다.
+	Quota     *AIQuota       `json:"quota,omitempty"`
+	Total     AIUsageTotal   `json:"total"`
+	ByFeature []AIUsageTotal `json:"byFeature"`
+	// 프롬프트 버전별 사용량 (버전이 있는 프롬프트로 호출한 기록만)
+	ByPromptVersion []AIUsageTotal `json:"byPromptVersion"`
+	// userId 없이 조회한 경우 비용이 큰 사용자 순 (최대 20명)
+	TopUsers []AIUsageTotal `json:"topUsers,omitempty"`
+}
+
+func (AIUsageModel) InsertUsage(usage AIUsage) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.AIUsageCollection.InsertOne(ctx, usage)
+
+	return err
+}
+
+// CountQuotaUsages 는 since 이후 한도에 포함되는 호출 수를 반환합니다. (캐시 결과, 임베딩 제외)
+func (AIUsageModel) CountQuotaUsages(userId string, since time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"user_id":    userId,
+		"created_at": bson.M{"$gte": since},
+		"cached":     false,
+		"feature":    bson.M{"$ne": FeatureEmbedding},
+	}
+
+	return db.AIUsageCollection.CountDocuments(ctx, filter)
+}
+
+// SumUsages 는 기간 내 사용량을 groupBy 필드(feature, user_id)별로 합산합니다. groupBy 가 비어 있으면 전체 합계 하나를 반환합니다.
+func (AIUsageModel) SumUsages(userId string, from, to time.Time, groupBy string, limit int64) ([]AIUsageTotal, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	match := bson.M{"created_at": bson.M{"$gte": from, "$lt": to}}
+	if userId != "" {
+		match["user_id"] = userId
+	}
+
+	var groupId interface{} = "total"
+	if groupBy != "" {
+		groupId = "$" + groupBy
+		// 그룹 기준 필드가 없는 기록(프롬프트 버전이 없는 호출 등)은 제외
+		match[groupBy] = bson.M{"$exists": true}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":               groupId,
+			"requests":          bson.M{"$sum": 1},
+			"cached_requests":   bson.M{"$sum": bson.M{"$cond": bson.A{"$cached", 1, 0}}},
+			"prompt_tokens":     bson.M{"$sum": "$prompt_tokens"},
+			"completion_tokens": bson.M{"$sum": "$completion_tokens"},
+			"cost_usd":          bson.M{"$sum": "$cost_usd"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "cost_usd", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+
+	cur, err := db.AIUsageCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	totals := []AIUsageTotal{}
+	if err := cur.All(ctx, &totals); err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
@@ -0,0 +1,169 @@
+package aiusage
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"joosum-backend/pkg/config"
+	"joosum-backend/pkg/util"
+)
+
+const (
+	// 설정(aiQuotaDaily, aiQuotaMonthly)이 없을 때의 사용자별 AI 호출 한도
+	defaultDailyQuota   = 100
+	defaultMonthlyQuota = 1000
+	// 사용량 조회 시 상위 사용자 수
+	topUsersLimit = 20
+)
+
+// 모델별 100만 토큰당 가격 (USD). 버전이 붙은 모델명(gpt-4o-mini-2024-07-18 등)은 접두사로 찾습니다.
+// 목록에 없는 모델(로컬 모델 등)은 비용 0 으로 기록합니다.
+var modelPrices = map[string]struct{ Input, Output float64 }{
+	"gpt-4o-mini":            {Input: 0.15, Output: 0.60},
+	"gpt-4o":                 {Input: 2.50, Output: 10.00},
+	"gpt-4.1-mini":           {Input: 0.40, Output: 1.60},
+	"gpt-4.1-nano":           {Input: 0.10, Output: 0.40},
+	"text-embedding-3-small": {Input: 0.02},
+	"text-embedding-3-large": {Input: 0.13},
+}
+
+// 한도는 한국 시간 기준 자정/매월 1일에 초기화
+var quotaLocation = loadQuotaLocation()
+
+type AIUsageUsecase struct {
+	aiUsageModel AIUsageModel
+}
+
+func loadQuotaLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		return time.FixedZone("KST", 9*60*60)
+	}
+	return loc
+}
+
+// Record 는 AI 호출 사용량과 비용을 기록합니다. 비용과 기록 시각은 여기서 채웁니다.
+// 기록에 실패해도 AI 기능은 계속 동작하도록 에러는 로그로만 남깁니다.
+func (u AIUsageUsecase) Record(usage AIUsage) {
+	usage.CostUSD = EstimateCost(usage.Model, usage.PromptTokens, usage.CompletionTokens)
+	usage.CreatedAt = time.Now()
+
+	if err := u.aiUsageModel.InsertUsage(usage); err != nil {
+		log.Printf("[AI 사용량] 기록 실패 (userId=%s, feature=%s): %v", usage.UserId, usage.Feature, err)
+	}
+}
+
+// GetQuota 는 사용자의 오늘/이번 달 AI 호출 수와 한도를 반환합니다.
+func (u AIUsageUsecase) GetQuota(userId string) (*AIQuota, error) {
+	now := time.Now().In(quotaLocation)
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, quotaLocation)
+	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, quotaLocation)
+
+	dailyUsed, err := u.aiUsageModel.CountQuotaUsages(userId, startOfDay)
+	if err != nil {
+		return nil, err
+	}
+
+	monthlyUsed, err := u.aiUsageModel.CountQuotaUsages(userId, startOfMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AIQuota{
+		DailyLimit:   getQuotaConfig("aiQuotaDaily", defaultDailyQuota),
+		DailyUsed:    dailyUsed,
+		MonthlyLimit: getQuotaConfig("aiQuotaMonthly", defaultMonthlyQuota),
+		MonthlyUsed:  monthlyUsed,
+	}, nil
+}
+
+// CheckQuota 는 사용자가 일/월 한도를 넘었으면 util.ErrAIQuotaExceeded 를 반환합니다.
+func (u AIUsageUsecase) CheckQuota(userId string) error {
+	quota, err := u.GetQuota(userId)
+	if err != nil {
+		return err
+	}
+
+	if quota.DailyUsed >= quota.DailyLimit || quota.MonthlyUsed >= quota.MonthlyLimit {
+		log.Printf("[AI 사용량] 한도 초과 (userId=%s, daily=%d/%d, monthly=%d/%d)",
+			userId, quota.DailyUsed, quota.DailyLimit, quota.MonthlyUsed, quota.MonthlyLimit)
+		return util.ErrAIQuotaExceeded
+	}
+
+	return nil
+}
+
+// GetUsageSummary 는 최근 days 일 동안의 사용량을 기능별로 합산합니다.
+// userId 가 있으면 해당 사용자의 사용량과 한도를, 없으면 전체 사용량과 상위 사용자를 반환합니다.
+func (u AIUsageUsecase) GetUsageSummary(userId string, days int) (*AIUsageSummaryRes, error) {
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+
+	res := &AIUsageSummaryRes{From: from, To: to, UserId: userId, Total: AIUsageTotal{Key: "total"}}
+
+	totals, err := u.aiUsageModel.SumUsages(userId, from, to, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(totals) > 0 {
+		res.Total = totals[0]
+	}
+
+	res.ByFeature, err = u.aiUsageModel.SumUsages(userId, from, to, "feature", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	res.ByPromptVersion, err = u.aiUsageModel.SumUsages(userId, from, to, "prompt_version", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if userId != "" {
+		res.Quota, err = u.GetQuota(userId)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+
+	res.TopUsers, err = u.aiUsageModel.SumUsages("", from, to, "user_id", topUsersLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// EstimateCost 는 모델 가격표로 호출 비용(USD)을 계산합니다.
+func EstimateCost(model string, promptTokens, completionTokens int) float64 {
+	var matched string
+	for name := range modelPrices {
+		if strings.HasPrefix(model, name) && len(name) > len(matched) {
+			matched = name
+		}
+	}
+	if matched == "" {
+		return 0
+	}
+
+	price := modelPrices[matched]
+	return (float64(promptTokens)*price.Input + float64(completionTokens)*price.Output) / 1_000_000
+}
+
+func getQuotaConfig(key string, defaultValue int64) int64 {
+	value := config.GetEnvConfig(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	limit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || limit < 0 {
+		log.Printf("[AI 사용량] 잘못된 %s 설정(%s), 기본값 %d 사용", key, value, defaultValue)
+		return defaultValue
+	}
+
+	return limit
+}
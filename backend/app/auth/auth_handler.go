@@ -94,14 +94,24 @@ func (h AuthHandler) SignUp(c *gin.Context) {
 // Logout
 // @Tags 유저
 // @Summary 로그아웃
-// @Description 디바이스 ID 삭제
-// @Success 200 {object} db.UpdateResult
+// @Description 로그아웃한 디바이스를 푸시 대상에서 삭제합니다. deviceId 를 보내지 않으면 모든 디바이스를 삭제합니다.
+// @Param request body LogoutReq false "request"
+// @Success 200 {object} LogoutRes
 // @Security ApiKeyAuth
 // @Router /auth/logout [POST]
 func (h AuthHandler) Logout(c *gin.Context) {
 	userId := user.GetUserId(c)
 
-	result, err := h.authUsecase.Logout(userId)
+	// 본문이 없는 예전 앱 요청도 허용
+	var req LogoutReq
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.SendError(c, http.StatusBadRequest, util.CodeInvalidRequestBody)
+			return
+		}
+	}
+
+	result, err := h.authUsecase.Logout(userId, req.DeviceId)
 	if err != nil {
 		// 500 Internal Server Error
 		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
@@ -140,9 +150,8 @@ func (h AuthHandler) GetMe(c *gin.Context) {
 		return
 	}
 
-	// 폴더 수 계산을 위한 링크북 목록 가져오기
-	req := link.LinkBookListReq{}
-	linkBooks, err := h.linkBookModel.GetLinkBooks(req, userId)
+	// 폴더 수 가져오기
+	linkBookCount, err := h.linkBookModel.GetUserLinkBookCount(userId)
 	if err != nil {
 		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
 		return
@@ -152,7 +161,7 @@ func (h AuthHandler) GetMe(c *gin.Context) {
 	response := gin.H{
 		"user":             currentUser,
 		"totalLinkCount":   linkCount,
-		"totalFolderCount": len(linkBooks),
+		"totalFolderCount": linkBookCount,
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -16,3 +16,13 @@ type SignUpInfo struct {
 	Age        uint32 `json:"age" example:"20"`
 	SignUpDate string `json:"sign_up_date"`
 }
+
+type LogoutReq struct {
+	// 로그아웃하는 디바이스의 토큰 (POST /settings/device 의 deviceId). 비우면 모든 디바이스에서 푸시를 끕니다.
+	DeviceId string `json:"deviceId"`
+}
+
+type LogoutRes struct {
+	// 푸시 대상에서 지운 디바이스 수
+	DeletedDevices int64 `json:"deletedDevices" example:"1"`
+}
@@ -15,15 +15,14 @@ import (
 
 	"github.com/go-resty/resty/v2"
 	"github.com/golang-jwt/jwt/v4"
-	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/api/oauth2/v2"
 	"google.golang.org/api/option"
 )
 
 type AuthUsecase struct {
-	salt         string
-	userModel    user.UserModel
-	settingModel setting.SettingModel
+	salt           string
+	userModel      user.UserModel
+	settingUsecase setting.SettingUsecase
 }
 
 func (u *AuthUsecase) GenerateNewJWTToken(email string) (string, string, error) {
@@ -47,12 +46,13 @@ func (u *AuthUsecase) SignUp(userInfo user.User) (*user.User, error) {
 	return user, nil
 }
 
-func (u *AuthUsecase) Logout(userId string) (*mongo.UpdateResult, error) {
-	result, err := u.settingModel.DeleteDivceId(userId)
+// Logout 은 로그아웃한 디바이스를 푸시 대상에서 지웁니다. deviceId 가 비어 있으면 모든 디바이스를 지웁니다.
+func (u *AuthUsecase) Logout(userId, deviceId string) (*LogoutRes, error) {
+	deleted, err := u.settingUsecase.Logout(userId, deviceId)
 	if err != nil {
 		return nil, err
 	}
-	return result, nil
+	return &LogoutRes{DeletedDevices: deleted}, nil
 }
 
 func (u *AuthUsecase) GetEmailFromJWT(social, idToken string) (string, error) {
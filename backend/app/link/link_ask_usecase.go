@@ -0,0 +1,320 @@
+package link
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"joosum-backend/app/aiusage"
+	"joosum-backend/pkg/llm"
+)
+
+const (
+	// 검색으로 후보를 고른 뒤 답변 컨텍스트로 사용하는 링크 수
+	askCandidateLinks = 10
+	askContextLinks   = 5
+	// 링크마다 넣는 본문 구간 수와 구간 길이
+	askPassagesPerLink = 2
+	askPassageLength   = 700
+	// 질문 최대 길이
+	MaxAskQuestionLength = 500
+)
+
+// 관련 링크를 찾지 못했을 때의 답변
+const askNoContextAnswer = "저장한 링크에서 질문과 관련된 내용을 찾지 못했어요."
+
+var askCitationPattern = regexp.MustCompile(`\[(\d+)\]`)
+
+type askSource struct {
+	Link     *Link
+	Passages []string
+}
+
+type askResponse struct {
+	Answer  string `json:"answer"`
+	Sources []int  `json:"sources"`
+}
+
+var askResponseFormat = llm.ResponseFormat{
+	Name: "link_answer",
+	Schema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"answer": {"type": "string"},
+			"sources": {"type": "array", "items": {"type": "integer"}}
+		},
+		"required": ["answer", "sources"],
+		"additionalProperties": false
+	}`),
+}
+
+// AskLinks 사용자가 저장한 링크의 본문을 근거로 질문에 답합니다
+// 시맨틱 검색으로 관련 링크를 고르고, 저장된 본문에서 질문과 가까운 구간을 LLM 에 전달합니다
+func (u LinkUsecase) AskLinks(userId string, question string) (*AskLinksRes, error) {
+	res := &AskLinksRes{Question: question, Citations: []AskCitation{}}
+
+	// 한도를 넘었으면 검색(임베딩) 전에 중단
+	provider, err := u.getMeteredLLMProvider(userId, aiusage.FeatureAsk, "")
+	if err != nil {
+		log.Printf("[링크 질문] LLM 제공자 생성 실패: %v", err)
+		return nil, err
+	}
+
+	candidates, err := u.SemanticSearch(userId, question, askCandidateLinks)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := u.buildAskSources(question, candidates)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		res.Answer = askNoContextAnswer
+		return res, nil
+	}
+
+	parsed, err := answerWithSources(provider, question, sources)
+	if err != nil {
+		log.Printf("[링크 질문] %s 답변 생성 실패 (userId=%s): %v", provider.Name(), userId, err)
+		return nil, fmt.Errorf("failed to answer question: %v", err)
+	}
+
+	res.Answer, res.Citations = citeSources(parsed, sources)
+
+	return res, nil
+}
+
+// buildAskSources 검색된 링크 중 본문(없으면 AI 요약)이 있는 링크를 골라 질문과 가까운 구간을 뽑습니다
+func (u LinkUsecase) buildAskSources(question string, candidates []SemanticSearchLink) ([]askSource, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	linkIds := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		linkIds = append(linkIds, c.LinkId)
+	}
+
+	contents, err := u.linkModel.GetLinkContentsByLinkIds(linkIds)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make(map[string]string, len(contents))
+	for _, content := range contents {
+		texts[content.LinkId] = content.Text
+	}
+
+	terms := questionTerms(question)
+
+	var sources []askSource
+	for i := range candidates {
+		link := &candidates[i].Link
+
+		var passages []string
+		if text, ok := texts[link.LinkId]; ok && text != "" {
+			passages = selectPassages(text, terms, askPassagesPerLink, askPassageLength)
+		} else if link.Summary != nil {
+			passages = []string{link.Summary.Summary + "\n" + strings.Join(link.Summary.KeyPoints, "\n")}
+		}
+		if len(passages) == 0 {
+			continue
+		}
+
+		sources = append(sources, askSource{Link: link, Passages: passages})
+		if len(sources) == askContextLinks {
+			break
+		}
+	}
+
+	return sources, nil
+}
+
+// answerWithSources LLM 을 호출하여 번호가 매겨진 출처를 근거로 답변을 받습니다
+func answerWithSources(provider llm.LLMProvider, question string, sources []askSource) (*askResponse, error) {
+	systemPrompt := `당신은 사용자가 저장한 글을 바탕으로 질문에 답하는 도우미입니다.
+
+다음 규칙을 엄격히 따라 답변하세요:
+
+- 주어진 출처의 내용만 근거로 답변하고, 출처에 없는 내용은 추측하지 않음
+- 근거가 된 문장 끝에 출처 번호를 [1], [2] 형식으로 표시
+- 출처에서 답을 찾을 수 없으면 찾을 수 없다고 답변하고 sources 는 빈 배열
+- 질문과 같은 언어로 5문장 이내로 답변
+- JSON 객체 형식으로만 응답: {"answer": "...", "sources": [1, 2]}`
+
+	var sourceText strings.Builder
+	for i, source := range sources {
+		fmt.Fprintf(&sourceText, "[%d] 제목: %s\nURL: %s\n", i+1, source.Link.Title, source.Link.URL)
+		for _, passage := range source.Passages {
+			sourceText.WriteString(passage)
+			sourceText.WriteString("\n")
+		}
+		sourceText.WriteString("\n")
+	}
+
+	userPrompt := fmt.Sprintf("출처:\n%s질문: %s\n\n위 출처를 근거로 질문에 답하고 JSON 객체로 반환하세요.", sourceText.String(), question)
+
+	req := llm.ChatRequest{
+		Messages: []llm.Message{
+			{
+				Role:    llm.RoleSystem,
+				Content: systemPrompt,
+			},
+			{
+				Role:    llm.RoleUser,
+				Content: userPrompt,
+			},
+		},
+		Temperature:    0.2,
+		MaxTokens:      700,
+		ResponseFormat: &askResponseFormat,
+	}
+
+	var parsed askResponse
+	resp, err := llm.ChatJSON(context.Background(), provider, req, &parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed.Answer = strings.TrimSpace(parsed.Answer)
+	if parsed.Answer == "" {
+		return nil, fmt.Errorf("empty answer in AI response: %s", resp.Content)
+	}
+
+	return &parsed, nil
+}
+
+// citeSources 답변에서 실제로 인용한 출처만 citations 로 만들고, 답변의 [n] 번호를 citations 순서로 다시 매깁니다
+// 없는 출처 번호는 답변에서 지웁니다
+func citeSources(parsed *askResponse, sources []askSource) (string, []AskCitation) {
+	cited := append([]int(nil), parsed.Sources...)
+	for _, match := range askCitationPattern.FindAllStringSubmatch(parsed.Answer, -1) {
+		n, _ := strconv.Atoi(match[1])
+		cited = append(cited, n)
+	}
+
+	// 답변에 처음 나온 순서가 아니라 출처 순서(관련도 순)로 정렬
+	sort.Ints(cited)
+
+	numbers := map[int]int{}
+	citations := []AskCitation{}
+	for _, n := range cited {
+		if n < 1 || n > len(sources) {
+			continue
+		}
+		if _, ok := numbers[n]; ok {
+			continue
+		}
+
+		link := sources[n-1].Link
+		citations = append(citations, AskCitation{LinkId: link.LinkId, Title: link.Title, URL: link.URL})
+		numbers[n] = len(citations)
+	}
+
+	answer := askCitationPattern.ReplaceAllStringFunc(parsed.Answer, func(marker string) string {
+		n, _ := strconv.Atoi(marker[1 : len(marker)-1])
+		if renumbered, ok := numbers[n]; ok {
+			return "[" + strconv.Itoa(renumbered) + "]"
+		}
+		return ""
+	})
+
+	return strings.TrimSpace(answer), citations
+}
+
+// questionTerms 질문에서 본문 구간을 고를 때 쓰는 검색어를 뽑습니다
+// 조사가 붙은 한글 단어도 찾을 수 있도록 마지막 글자를 뺀 형태도 함께 넣습니다
+func questionTerms(question string) []string {
+	words := strings.FieldsFunc(strings.ToLower(question), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	seen := map[string]bool{}
+	var terms []string
+	add := func(term string) {
+		if len([]rune(term)) < 2 || seen[term] {
+			return
+		}
+		seen[term] = true
+		terms = append(terms, term)
+	}
+
+	for _, word := range words {
+		add(word)
+		if runes := []rune(word); len(runes) >= 3 {
+			add(string(runes[:len(runes)-1]))
+		}
+	}
+
+	return terms
+}
+
+// selectPassages 본문을 length 글자 단위 구간으로 나눠 검색어가 많이 나오는 구간을 최대 count 개 고릅니다
+// 고른 구간은 본문 순서대로 반환하며, 검색어가 나오는 구간이 없으면 첫 구간을 반환합니다
+func selectPassages(text string, terms []string, count int, length int) []string {
+	runes := []rune(text)
+
+	type passage struct {
+		start int
+		text  string
+		score int
+	}
+
+	var passages []passage
+	for start := 0; start < len(runes); start += length {
+		end := start + length
+		if end > len(runes) {
+			end = len(runes)
+		}
+
+		chunk := strings.TrimSpace(string(runes[start:end]))
+		if chunk == "" {
+			continue
+		}
+
+		lower := strings.ToLower(chunk)
+		score := 0
+		for _, term := range terms {
+			score += strings.Count(lower, term)
+		}
+		passages = append(passages, passage{start: start, text: chunk, score: score})
+	}
+
+	if len(passages) == 0 {
+		return nil
+	}
+
+	ranked := append([]passage(nil), passages...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+	if ranked[0].score == 0 {
+		return []string{passages[0].text}
+	}
+
+	var selected []passage
+	for _, p := range ranked {
+		if p.score == 0 || len(selected) == count {
+			break
+		}
+		selected = append(selected, p)
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].start < selected[j].start
+	})
+
+	result := make([]string, 0, len(selected))
+	for _, p := range selected {
+		result = append(result, p.text)
+	}
+
+	return result
+}
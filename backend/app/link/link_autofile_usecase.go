@@ -0,0 +1,489 @@
+package link
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"joosum-backend/app/aiusage"
+	localConfig "joosum-backend/pkg/config"
+	"joosum-backend/pkg/llm"
+	"joosum-backend/pkg/util"
+)
+
+const (
+	// 자동 분류 설정(autoFileConfidence)이 없을 때 링크를 옮기는 최소 확신도
+	defaultAutoFileConfidence = 0.8
+	// 한 번의 자동 분류 요청에서 분류하는 최대 링크 수 (LLM 호출 수 제한)
+	maxAutoFileLinks = 20
+	// 추천 폴더 최대 개수
+	maxLinkBookSuggestions = 3
+	// 폴더 설명에 넣는 폴더별 최근 링크 제목 수
+	linkBookSampleTitles = 5
+	// 분류에 사용하는 링크 본문 최대 길이
+	maxClassifyContentLength = 2000
+	// 자동 분류 기록 조회 개수
+	autoFilingHistoryLimit = 50
+)
+
+// linkBookCandidate 는 분류 대상 폴더와 그 폴더의 최근 링크 제목입니다
+type linkBookCandidate struct {
+	LinkBookId   string
+	Title        string
+	RecentTitles []string
+}
+
+type linkBookSuggestionResponse struct {
+	LinkBookId string  `json:"linkBookId"`
+	Confidence float64 `json:"confidence"`
+	Reason     string  `json:"reason"`
+}
+
+// 폴더 추천 응답 스키마. structured output 은 최상위가 객체여야 하므로 배열을 suggestions 로 감쌉니다.
+var linkBookSuggestionResponseFormat = llm.ResponseFormat{
+	Name: "link_book_suggestions",
+	Schema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"suggestions": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {
+						"linkBookId": {"type": "string"},
+						"confidence": {"type": "number"},
+						"reason": {"type": "string"}
+					},
+					"required": ["linkBookId", "confidence", "reason"],
+					"additionalProperties": false
+				}
+			}
+		},
+		"required": ["suggestions"],
+		"additionalProperties": false
+	}`),
+}
+
+// SuggestLinkBooks 링크 내용과 사용자의 폴더를 비교해 어울리는 폴더를 확신도 순으로 추천합니다
+func (u LinkUsecase) SuggestLinkBooks(userId string, linkId string) (*LinkBookSuggestionRes, error) {
+	link, err := u.getOwnedLink(userId, linkId)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := u.getLinkBookCandidates(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &LinkBookSuggestionRes{LinkId: linkId, Suggestions: []LinkBookSuggestion{}}
+	if len(candidates) == 0 {
+		return res, nil
+	}
+
+	provider, err := u.getMeteredLLMProvider(userId, aiusage.FeatureLinkBookSuggestion, "")
+	if err != nil {
+		log.Printf("[AI 폴더 추천] LLM 제공자 생성 실패: %v", err)
+		return nil, err
+	}
+
+	suggestions, err := classifyLink(provider, u.describeLinkForClassify(link, true), candidates)
+	if err != nil {
+		log.Printf("[AI 폴더 추천] %s 분류 실패 (linkId=%s): %v", provider.Name(), linkId, err)
+		return nil, fmt.Errorf("failed to get AI link book suggestions: %v", err)
+	}
+	res.Suggestions = suggestions
+
+	return res, nil
+}
+
+// AutoFileLinks 기본 폴더의 링크를 분류해 확신도가 높은 링크는 추천 폴더로 옮깁니다
+// 옮긴 링크마다 되돌리기용 기록을 남기고, 확신도가 낮은 링크는 추천만 반환합니다
+// 링크마다 AI 사용량 한도를 확인해 한도에 도달하면 남은 링크는 분류하지 않고, 분류하지 못한 링크는 Skipped 로 반환합니다
+func (u LinkUsecase) AutoFileLinks(userId string, linkIds []string) (*AutoFileLinksRes, error) {
+	defaultLinkBook, err := u.linkBookModel.GetDefaultLinkBook(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := u.getAutoFileTargets(userId, defaultLinkBook.LinkBookId, linkIds)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &AutoFileLinksRes{Filed: []LinkAutoFiling{}, Suggested: []LinkBookSuggestionRes{}, Skipped: []string{}}
+	if len(links) == 0 {
+		return res, nil
+	}
+
+	candidates, err := u.getLinkBookCandidates(userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return res, nil
+	}
+
+	provider, err := u.getMeteredLLMProvider(userId, aiusage.FeatureLinkBookSuggestion, "")
+	if err != nil {
+		log.Printf("[AI 자동 분류] LLM 제공자 생성 실패: %v", err)
+		return nil, err
+	}
+
+	threshold := getAutoFileConfidence()
+	for i, link := range links {
+		// 링크마다 LLM 을 (파싱 실패 시 두 번) 호출하므로 한도를 다시 확인. 첫 링크는 위에서 확인함
+		if i > 0 {
+			if err := u.aiUsageUsecase.CheckQuota(userId); err != nil {
+				log.Printf("[AI 자동 분류] 남은 링크 %d개 분류 중단 (userId=%s): %v", len(links)-i, userId, err)
+				for _, skipped := range links[i:] {
+					res.Skipped = append(res.Skipped, skipped.LinkId)
+				}
+				break
+			}
+		}
+
+		suggestions, err := classifyLink(provider, u.describeLinkForClassify(link, false), candidates)
+		if err != nil {
+			// 한 링크의 분류 실패로 전체 요청을 실패시키지 않음
+			log.Printf("[AI 자동 분류] %s 분류 실패 (linkId=%s): %v", provider.Name(), link.LinkId, err)
+			res.Skipped = append(res.Skipped, link.LinkId)
+			continue
+		}
+		if len(suggestions) == 0 {
+			continue
+		}
+
+		best := suggestions[0]
+		if best.Confidence < threshold {
+			res.Suggested = append(res.Suggested, LinkBookSuggestionRes{LinkId: link.LinkId, Suggestions: suggestions})
+			continue
+		}
+
+		filing, err := u.fileLink(userId, link.LinkId, defaultLinkBook, best)
+		if err != nil {
+			// 이 요청에서 이미 옮긴 링크의 기록을 돌려주도록 요청을 실패시키지 않음
+			log.Printf("[AI 자동 분류] 링크 이동 실패 (linkId=%s): %v", link.LinkId, err)
+			res.Skipped = append(res.Skipped, link.LinkId)
+			continue
+		}
+		if filing != nil {
+			res.Filed = append(res.Filed, *filing)
+		}
+	}
+
+	return res, nil
+}
+
+// GetAutoFilings 사용자의 최근 자동 분류 기록을 반환합니다
+func (u LinkUsecase) GetAutoFilings(userId string) ([]LinkAutoFiling, error) {
+	return u.linkModel.GetLinkAutoFilingsByUserId(userId, autoFilingHistoryLimit)
+}
+
+// UndoAutoFiling 자동 분류로 옮긴 링크를 원래 폴더로 되돌립니다
+// 그 사이 사용자가 링크를 다른 폴더로 옮겼다면 되돌리지 않습니다
+func (u LinkUsecase) UndoAutoFiling(userId string, autoFilingId string) (*LinkAutoFiling, error) {
+	filing, err := u.linkModel.GetLinkAutoFiling(autoFilingId)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, util.ErrAutoFilingNotFound
+		}
+		return nil, err
+	}
+
+	if filing.UserId != userId {
+		return nil, util.ErrAutoFilingNotFound
+	}
+
+	if filing.UndoneAt != nil {
+		return nil, util.ErrAutoFilingNotUndoable
+	}
+
+	moved, err := u.linkModel.MoveLinkIfInLinkBook(filing.LinkId, filing.ToLinkBookId, filing.FromLinkBookId, filing.FromLinkBookName)
+	if err != nil {
+		return nil, err
+	}
+	if !moved {
+		return nil, util.ErrAutoFilingNotUndoable
+	}
+
+	// 동시에 되돌린 요청이 먼저 처리됐으면 되돌릴 수 없는 기록으로 응답
+	undone, err := u.linkModel.MarkLinkAutoFilingUndone(autoFilingId)
+	if err != nil {
+		return nil, err
+	}
+	if !undone {
+		return nil, util.ErrAutoFilingNotUndoable
+	}
+
+	now := time.Now()
+	filing.UndoneAt = &now
+
+	return filing, nil
+}
+
+// getOwnedLink 사용자가 소유한 링크를 조회합니다. 다른 사용자의 링크는 없는 링크로 취급합니다
+func (u LinkUsecase) getOwnedLink(userId string, linkId string) (*Link, error) {
+	link, err := u.linkModel.GetOneLinkByLinkId(linkId)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, util.ErrLinkNotFound
+		}
+		return nil, err
+	}
+
+	if link.UserID != userId {
+		return nil, util.ErrLinkNotFound
+	}
+
+	return link, nil
+}
+
+// getAutoFileTargets 자동 분류할 기본 폴더의 링크를 최대 maxAutoFileLinks 개 반환합니다
+// linkIds 가 비어 있으면 기본 폴더의 최근 링크를 사용합니다
+func (u LinkUsecase) getAutoFileTargets(userId string, defaultLinkBookId string, linkIds []string) ([]*Link, error) {
+	if len(linkIds) == 0 {
+		links, err := u.linkModel.GetAllLinkByUserIdAndLinkBookIdAndSearch(userId, defaultLinkBookId, "", "created_at", "desc")
+		if err != nil {
+			return nil, err
+		}
+		if len(links) > maxAutoFileLinks {
+			links = links[:maxAutoFileLinks]
+		}
+		return links, nil
+	}
+
+	var links []*Link
+	for _, linkId := range linkIds {
+		link, err := u.linkModel.GetOneLinkByLinkId(linkId)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				continue
+			}
+			return nil, err
+		}
+
+		// 기본 폴더에 있는 본인 링크만 자동 분류
+		if link.UserID != userId || link.LinkBookId != defaultLinkBookId {
+			continue
+		}
+
+		links = append(links, link)
+		if len(links) == maxAutoFileLinks {
+			break
+		}
+	}
+
+	return links, nil
+}
+
+// getLinkBookCandidates 기본 폴더를 제외한 사용자의 폴더와 폴더별 최근 링크 제목을 반환합니다
+func (u LinkUsecase) getLinkBookCandidates(userId string) ([]linkBookCandidate, error) {
+	linkBooks, err := u.linkBookModel.GetLinkBooks(LinkBookListReq{}, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	recentTitles, err := u.linkModel.GetRecentLinkTitlesByLinkBook(userId, linkBookSampleTitles)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []linkBookCandidate
+	for _, linkBook := range linkBooks {
+		if linkBook.IsDefault == "y" {
+			continue
+		}
+		candidates = append(candidates, linkBookCandidate{
+			LinkBookId:   linkBook.LinkBookId,
+			Title:        linkBook.Title,
+			RecentTitles: recentTitles[linkBook.LinkBookId],
+		})
+	}
+
+	return candidates, nil
+}
+
+// describeLinkForClassify 분류에 사용할 링크 설명을 만듭니다
+// fetch 가 true 이고 저장된 본문이 없으면 페이지를 가져와 본문을 저장합니다
+func (u LinkUsecase) describeLinkForClassify(link *Link, fetch bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "제목: %s\nURL: %s\n", link.Title, link.URL)
+	if len(link.Tags) > 0 {
+		fmt.Fprintf(&b, "태그: %s\n", strings.Join(link.Tags, ", "))
+	}
+	if link.Summary != nil {
+		fmt.Fprintf(&b, "요약: %s\n", link.Summary.Summary)
+	}
+
+	var content string
+	if stored, err := u.linkModel.GetLinkContent(link.LinkId); err == nil {
+		content = stored.Text
+	} else if fetch {
+		if text, err := u.getLinkContent(link); err == nil {
+			content = text
+		}
+	}
+
+	if content != "" {
+		runes := []rune(content)
+		if len(runes) > maxClassifyContentLength {
+			content = string(runes[:maxClassifyContentLength]) + "..."
+		}
+		fmt.Fprintf(&b, "본문:\n%s\n", content)
+	}
+
+	return b.String()
+}
+
+// fileLink 링크를 추천 폴더로 옮기고 되돌리기용 기록을 남깁니다
+// 분류하는 사이 링크가 기본 폴더를 벗어났다면 옮기지 않고 nil 을 반환합니다
+func (u LinkUsecase) fileLink(userId string, linkId string, from *LinkBook, to LinkBookSuggestion) (*LinkAutoFiling, error) {
+	moved, err := u.linkModel.MoveLinkIfInLinkBook(linkId, from.LinkBookId, to.LinkBookId, to.Title)
+	if err != nil {
+		return nil, err
+	}
+	if !moved {
+		return nil, nil
+	}
+
+	err = u.linkBookModel.UpdateLinkBookLastSavedAt(to.LinkBookId)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBufferString("AutoFiling-")
+	buf.WriteString(uuid.New().String())
+
+	filing := LinkAutoFiling{
+		AutoFilingId:     buf.String(),
+		UserId:           userId,
+		LinkId:           linkId,
+		FromLinkBookId:   from.LinkBookId,
+		FromLinkBookName: from.Title,
+		ToLinkBookId:     to.LinkBookId,
+		ToLinkBookName:   to.Title,
+		Confidence:       to.Confidence,
+		CreatedAt:        time.Now(),
+	}
+
+	err = u.linkModel.CreateLinkAutoFiling(filing)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filing, nil
+}
+
+// getAutoFileConfidence 링크를 자동으로 옮기는 최소 확신도를 설정(autoFileConfidence)에서 읽습니다
+func getAutoFileConfidence() float64 {
+	value := localConfig.GetEnvConfig("autoFileConfidence")
+	if value == "" {
+		return defaultAutoFileConfidence
+	}
+
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil || threshold <= 0 || threshold > 1 {
+		log.Printf("[AI 자동 분류] 잘못된 autoFileConfidence 설정(%s), 기본값 %.2f 사용", value, defaultAutoFileConfidence)
+		return defaultAutoFileConfidence
+	}
+
+	return threshold
+}
+
+// classifyLink LLM 을 호출하여 링크에 어울리는 폴더를 확신도 순으로 추천받습니다
+// 사용자의 폴더가 아닌 응답은 버립니다
+func classifyLink(provider llm.LLMProvider, linkDescription string, candidates []linkBookCandidate) ([]LinkBookSuggestion, error) {
+	systemPrompt := `당신은 사용자가 저장한 링크를 사용자의 폴더로 분류하는 전문가입니다.
+
+다음 규칙을 엄격히 따라 폴더를 추천하세요:
+
+- 반드시 주어진 폴더 목록의 linkBookId 중에서만 추천
+- 폴더 제목과 폴더에 이미 저장된 링크 제목을 보고 폴더의 주제를 판단
+- 링크의 주제와 맞는 폴더만 최대 3개까지 추천
+- confidence: 링크가 그 폴더에 들어가야 한다는 확신도 (0~1). 애매하면 낮게 설정
+- reason: 추천 이유를 한 문장으로 작성
+- 어울리는 폴더가 없으면 suggestions 를 빈 배열로 반환
+- JSON 객체 형식으로만 응답: {"suggestions": [{"linkBookId": "...", "confidence": 0.9, "reason": "..."}]}`
+
+	var folders strings.Builder
+	for _, c := range candidates {
+		fmt.Fprintf(&folders, "- linkBookId: %s, 제목: %s", c.LinkBookId, c.Title)
+		if len(c.RecentTitles) > 0 {
+			fmt.Fprintf(&folders, ", 최근 링크: %s", strings.Join(c.RecentTitles, " | "))
+		}
+		folders.WriteString("\n")
+	}
+
+	userPrompt := fmt.Sprintf("폴더 목록:\n%s\n분류할 링크:\n%s\n위 링크에 어울리는 폴더를 {\"suggestions\": [...]} 형식의 JSON 으로 반환하세요.", folders.String(), linkDescription)
+
+	req := llm.ChatRequest{
+		Messages: []llm.Message{
+			{
+				Role:    llm.RoleSystem,
+				Content: systemPrompt,
+			},
+			{
+				Role:    llm.RoleUser,
+				Content: userPrompt,
+			},
+		},
+		Temperature:    0.2,
+		MaxTokens:      300,
+		ResponseFormat: &linkBookSuggestionResponseFormat,
+	}
+
+	var parsed struct {
+		Suggestions []linkBookSuggestionResponse `json:"suggestions"`
+	}
+	_, err := llm.ChatJSON(context.Background(), provider, req, &parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make(map[string]string, len(candidates))
+	for _, c := range candidates {
+		titles[c.LinkBookId] = c.Title
+	}
+
+	seen := make(map[string]bool)
+	suggestions := []LinkBookSuggestion{}
+	for _, p := range parsed.Suggestions {
+		title, ok := titles[p.LinkBookId]
+		if !ok || seen[p.LinkBookId] {
+			continue
+		}
+		seen[p.LinkBookId] = true
+
+		confidence := p.Confidence
+		if confidence < 0 {
+			confidence = 0
+		} else if confidence > 1 {
+			confidence = 1
+		}
+
+		suggestions = append(suggestions, LinkBookSuggestion{
+			LinkBookId: p.LinkBookId,
+			Title:      title,
+			Confidence: confidence,
+			Reason:     strings.TrimSpace(p.Reason),
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Confidence > suggestions[j].Confidence
+	})
+	if len(suggestions) > maxLinkBookSuggestions {
+		suggestions = suggestions[:maxLinkBookSuggestions]
+	}
+
+	return suggestions, nil
+}
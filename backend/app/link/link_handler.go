@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"joosum-backend/app/tag"
 	"joosum-backend/app/user"
@@ -40,6 +42,7 @@ type DeleteLinkReq struct {
 // @Tags 링크
 // @Summary AI 태그 추천
 // @Description URL의 본문 내용을 분석하여 검색에 유용한 태그 최대 5개를 AI로 추천합니다
+// @Description 사용자가 이미 가진 태그와 같은 의미의 태그는 기존 태그로 맞춰 추천하며, `tags` 의 `isExisting` 으로 기존/새 태그를 구분합니다
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
@@ -47,10 +50,20 @@ type DeleteLinkReq struct {
 // @Success 200 {object} AITagRecommendationRes "AI 태그 추천이 성공적으로 이루어졌을 때 추천 태그 목록 반환"
 // @Failure 400 {object} util.APIError "요청 본문이 유효하지 않을 때 반환합니다."
 // @Failure 401 {object} util.APIError "Authorization 헤더가 없을 때 반환합니다."
+// @Failure 429 {object} util.APIError "AI 기능 사용량 한도(일/월)를 넘었을 때 반환합니다."
 // @Failure 500 {object} util.APIError "AI 태그 추천 과정에서 오류가 발생한 경우 반환합니다."
 // @Security ApiKeyAuth
 // @Router /links/ai-tags [post]
 func (h LinkHandler) GetAIRecommendedTags(c *gin.Context) {
+	currentUser, exists := c.Get("user")
+	if !exists {
+		// 401 Unauthorized
+		util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+		return
+	}
+
+	userId := currentUser.(*user.User).UserId
+
 	var req AITagRecommendationReq
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Printf("[AI 태그 추천] 요청 바인딩 실패: %v", err)
@@ -63,8 +76,12 @@ func (h LinkHandler) GetAIRecommendedTags(c *gin.Context) {
 		return
 	}
 
-	result, err := h.linkUsecase.GetAIRecommendedTags(req.URL)
+	result, err := h.linkUsecase.GetAIRecommendedTags(userId, req.URL)
 	if err != nil {
+		if err == util.ErrAIQuotaExceeded {
+			util.SendError(c, http.StatusTooManyRequests, util.CodeAIQuotaExceeded)
+			return
+		}
 		log.Printf("[AI 태그 추천] URL=%s 처리 중 오류: %v", req.URL, err)
 		c.Error(fmt.Errorf("GetAIRecommendedTags failed: %v", err))
 		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
@@ -74,6 +91,346 @@ func (h LinkHandler) GetAIRecommendedTags(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// GenerateLinkSummary
+// @Tags 링크
+// @Summary AI 링크 요약 생성
+// @Description 링크 본문을 AI 로 요약하여 링크에 저장합니다. 요약과 3~5개의 핵심 포인트는 본문 언어(한국어/영어)로 작성됩니다.
+// @Description 이미 요약이 있는 링크도 다시 호출하면 최신 본문으로 요약을 새로 생성합니다. 저장된 요약은 링크 조회 시 `summary` 로 함께 반환됩니다.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param linkId path string true "링크 아이디"
+// @Success 200 {object} Link "요약이 저장된 링크를 반환합니다."
+// @Failure 401 {object} util.APIError "Authorization 헤더가 없을 때 반환합니다."
+// @Failure 404 {object} util.APIError "링크 아이디에 해당하는 링크가 없을 때 반환합니다."
+// @Failure 429 {object} util.APIError "AI 기능 사용량 한도(일/월)를 넘었을 때 반환합니다."
+// @Failure 500 {object} util.APIError "AI 요약 과정에서 오류가 발생한 경우 반환합니다."
+// @Router /links/{linkId}/summary [post]
+func (h LinkHandler) GenerateLinkSummary(c *gin.Context) {
+	currentUser, exists := c.Get("user")
+	if !exists {
+		// 401 Unauthorized
+		util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+		return
+	}
+
+	userId := currentUser.(*user.User).UserId
+	linkId := c.Param("linkId")
+
+	link, err := h.linkUsecase.GenerateLinkSummary(userId, linkId)
+	if err != nil {
+		if err == util.ErrAIQuotaExceeded {
+			util.SendError(c, http.StatusTooManyRequests, util.CodeAIQuotaExceeded)
+			return
+		}
+		if err == util.ErrLinkNotFound {
+			util.SendError(c, http.StatusNotFound, util.CodeLinkNotFound)
+			return
+		}
+		log.Printf("[AI 요약] linkId=%s 처리 중 오류: %v", linkId, err)
+		c.Error(fmt.Errorf("GenerateLinkSummary failed: %v", err))
+		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// GetLinkBookSuggestions
+// @Tags 링크
+// @Summary AI 폴더 추천
+// @Description 링크 내용과 사용자의 폴더(제목, 폴더에 저장된 링크)를 비교해 어울리는 폴더를 확신도(0~1) 순으로 최대 3개 추천합니다.
+// @Description 기본 폴더는 추천하지 않으며, 기본 폴더 외의 폴더가 없으면 빈 배열을 반환합니다.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param linkId path string true "링크 아이디"
+// @Success 200 {object} LinkBookSuggestionRes "추천 폴더 목록을 반환합니다."
+// @Failure 401 {object} util.APIError "Authorization 헤더가 없을 때 반환합니다."
+// @Failure 404 {object} util.APIError "링크 아이디에 해당하는 링크가 없을 때 반환합니다."
+// @Failure 429 {object} util.APIError "AI 기능 사용량 한도(일/월)를 넘었을 때 반환합니다."
+// @Failure 500 {object} util.APIError "AI 폴더 추천 과정에서 오류가 발생한 경우 반환합니다."
+// @Router /links/{linkId}/link-book-suggestions [get]
+func (h LinkHandler) GetLinkBookSuggestions(c *gin.Context) {
+	currentUser, exists := c.Get("user")
+	if !exists {
+		// 401 Unauthorized
+		util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+		return
+	}
+
+	userId := currentUser.(*user.User).UserId
+	linkId := c.Param("linkId")
+
+	result, err := h.linkUsecase.SuggestLinkBooks(userId, linkId)
+	if err != nil {
+		if err == util.ErrAIQuotaExceeded {
+			util.SendError(c, http.StatusTooManyRequests, util.CodeAIQuotaExceeded)
+			return
+		}
+		if err == util.ErrLinkNotFound {
+			util.SendError(c, http.StatusNotFound, util.CodeLinkNotFound)
+			return
+		}
+		log.Printf("[AI 폴더 추천] linkId=%s 처리 중 오류: %v", linkId, err)
+		c.Error(fmt.Errorf("GetLinkBookSuggestions failed: %v", err))
+		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// AutoFileLinks
+// @Tags 링크
+// @Summary AI 자동 분류
+// @Description 기본 폴더의 링크를 AI 로 분류해 확신도가 설정값(autoFileConfidence, 기본 0.8) 이상인 링크는 추천 폴더로 옮깁니다.
+// @Description linkIds 를 비우면 기본 폴더의 최근 링크를, 넣으면 그 중 기본 폴더에 있는 링크를 한 번에 최대 20개까지 분류합니다.
+// @Description 옮긴 링크는 `filed` 의 autoFilingId 로 되돌릴 수 있고, 확신도가 낮은 링크는 옮기지 않고 `suggested` 로 추천만 반환합니다.
+// @Description 링크마다 AI 사용량 한도를 확인해 한도에 도달하면 남은 링크는 분류하지 않으며, 한도나 오류로 분류하지 못한 링크는 `skipped` 로 반환합니다.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body AutoFileLinksReq false "자동 분류할 링크 아이디 목록"
+// @Success 200 {object} AutoFileLinksRes "옮긴 링크의 기록과 추천 폴더를 반환합니다."
+// @Failure 400 {object} util.APIError "요청 본문이 유효하지 않을 때 반환합니다."
+// @Failure 401 {object} util.APIError "Authorization 헤더가 없을 때 반환합니다."
+// @Failure 429 {object} util.APIError "AI 기능 사용량 한도(일/월)를 넘었을 때 반환합니다."
+// @Failure 500 {object} util.APIError "AI 자동 분류 과정에서 오류가 발생한 경우 반환합니다."
+// @Router /links/auto-file [post]
+func (h LinkHandler) AutoFileLinks(c *gin.Context) {
+	currentUser, exists := c.Get("user")
+	if !exists {
+		// 401 Unauthorized
+		util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+		return
+	}
+
+	userId := currentUser.(*user.User).UserId
+
+	var req AutoFileLinksReq
+	// 본문 없이 호출하면 기본 폴더의 최근 링크를 분류
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.SendError(c, http.StatusBadRequest, util.CodeInvalidRequestBody)
+			return
+		}
+	}
+
+	result, err := h.linkUsecase.AutoFileLinks(userId, req.LinkIds)
+	if err != nil {
+		if err == util.ErrAIQuotaExceeded {
+			util.SendError(c, http.StatusTooManyRequests, util.CodeAIQuotaExceeded)
+			return
+		}
+		log.Printf("[AI 자동 분류] userId=%s 처리 중 오류: %v", userId, err)
+		c.Error(fmt.Errorf("AutoFileLinks failed: %v", err))
+		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetAutoFilings
+// @Tags 링크
+// @Summary AI 자동 분류 기록 조회
+// @Description 최근 자동 분류 기록을 최대 50개까지 최신순으로 반환합니다. 되돌린 기록은 undoneAt 이 채워져 있습니다.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} LinkAutoFiling "자동 분류 기록을 반환합니다."
+// @Failure 401 {object} util.APIError "Authorization 헤더가 없을 때 반환합니다."
+// @Failure 500 {object} util.APIError "서버 오류가 발생한 경우 반환합니다."
+// @Router /links/auto-file [get]
+func (h LinkHandler) GetAutoFilings(c *gin.Context) {
+	currentUser, exists := c.Get("user")
+	if !exists {
+		// 401 Unauthorized
+		util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+		return
+	}
+
+	userId := currentUser.(*user.User).UserId
+
+	filings, err := h.linkUsecase.GetAutoFilings(userId)
+	if err != nil {
+		c.Error(fmt.Errorf("GetAutoFilings failed: %v", err))
+		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, filings)
+}
+
+// UndoAutoFiling
+// @Tags 링크
+// @Summary AI 자동 분류 되돌리기
+// @Description 자동 분류로 옮긴 링크를 원래 폴더로 되돌립니다. 그 사이 링크를 다른 폴더로 옮겼거나 이미 되돌린 경우에는 되돌리지 않습니다.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param autoFilingId path string true "자동 분류 기록 아이디"
+// @Success 200 {object} LinkAutoFiling "되돌린 자동 분류 기록을 반환합니다."
+// @Failure 401 {object} util.APIError "Authorization 헤더가 없을 때 반환합니다."
+// @Failure 404 {object} util.APIError "자동 분류 기록이 없을 때 반환합니다."
+// @Failure 409 {object} util.APIError "이미 다른 폴더로 옮겨졌거나 되돌린 링크일 때 반환합니다."
+// @Failure 500 {object} util.APIError "서버 오류가 발생한 경우 반환합니다."
+// @Router /links/auto-file/{autoFilingId}/undo [post]
+func (h LinkHandler) UndoAutoFiling(c *gin.Context) {
+	currentUser, exists := c.Get("user")
+	if !exists {
+		// 401 Unauthorized
+		util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+		return
+	}
+
+	userId := currentUser.(*user.User).UserId
+	autoFilingId := c.Param("autoFilingId")
+
+	filing, err := h.linkUsecase.UndoAutoFiling(userId, autoFilingId)
+	if err != nil {
+		switch err {
+		case util.ErrAutoFilingNotFound:
+			util.SendError(c, http.StatusNotFound, util.CodeAutoFilingNotFound)
+		case util.ErrAutoFilingNotUndoable:
+			util.SendError(c, http.StatusConflict, util.CodeAutoFilingNotUndoable)
+		default:
+			c.Error(fmt.Errorf("UndoAutoFiling failed: %v", err))
+			util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, filing)
+}
+
+// CreateLinkReminder
+// @Tags 링크
+// @Summary 링크 리마인더 예약
+// @Description 링크를 정한 시각에 다시 알려주는 푸시를 예약합니다. remindAt(RFC3339) 또는 preset 중 하나를 보냅니다.
+// @Description preset 은 알림 설정의 시간대 기준으로 tonight(오늘 21시, 지났으면 내일 21시), weekend(토요일 10시), nextWeek(다음 주 월요일 9시)입니다.
+// @Description 링크에 예약된 리마인더가 있으면 새 시각으로 바꾸며, 알림 시각 전에 링크를 읽거나 삭제하면 자동으로 취소됩니다.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param linkId path string true "링크 아이디"
+// @Param request body CreateLinkReminderReq true "알림 시각"
+// @Success 200 {object} LinkReminder "예약한 리마인더를 반환합니다."
+// @Failure 400 {object} util.APIError "요청 본문이 유효하지 않거나 알림 시각이 지났거나 1년 이후일 때 반환합니다."
+// @Failure 401 {object} util.APIError "Authorization 헤더가 없을 때 반환합니다."
+// @Failure 404 {object} util.APIError "링크 아이디에 해당하는 링크가 없을 때 반환합니다."
+// @Failure 500 {object} util.APIError "서버 오류가 발생한 경우 반환합니다."
+// @Router /links/{linkId}/reminders [post]
+func (h LinkHandler) CreateLinkReminder(c *gin.Context) {
+	currentUser, exists := c.Get("user")
+	if !exists {
+		// 401 Unauthorized
+		util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+		return
+	}
+
+	userId := currentUser.(*user.User).UserId
+	linkId := c.Param("linkId")
+
+	var req CreateLinkReminderReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.SendError(c, http.StatusBadRequest, util.CodeInvalidRequestBody)
+		return
+	}
+
+	reminder, err := h.linkUsecase.CreateLinkReminder(userId, linkId, req)
+	if err != nil {
+		switch err {
+		case util.ErrInvalidReminderTime:
+			util.SendError(c, http.StatusBadRequest, util.CodeInvalidReminderTime)
+		case util.ErrLinkNotFound:
+			util.SendError(c, http.StatusNotFound, util.CodeLinkNotFound)
+		default:
+			c.Error(fmt.Errorf("CreateLinkReminder failed: %v", err))
+			util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, reminder)
+}
+
+// GetLinkReminders
+// @Tags 링크
+// @Summary 링크 리마인더 조회
+// @Description 링크에 예약된(아직 보내지 않은) 리마인더를 반환합니다.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param linkId path string true "링크 아이디"
+// @Success 200 {array} LinkReminder "예약된 리마인더를 반환합니다."
+// @Failure 401 {object} util.APIError "Authorization 헤더가 없을 때 반환합니다."
+// @Failure 404 {object} util.APIError "링크 아이디에 해당하는 링크가 없을 때 반환합니다."
+// @Failure 500 {object} util.APIError "서버 오류가 발생한 경우 반환합니다."
+// @Router /links/{linkId}/reminders [get]
+func (h LinkHandler) GetLinkReminders(c *gin.Context) {
+	currentUser, exists := c.Get("user")
+	if !exists {
+		// 401 Unauthorized
+		util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+		return
+	}
+
+	userId := currentUser.(*user.User).UserId
+
+	reminders, err := h.linkUsecase.GetLinkReminders(userId, c.Param("linkId"))
+	if err != nil {
+		if err == util.ErrLinkNotFound {
+			util.SendError(c, http.StatusNotFound, util.CodeLinkNotFound)
+			return
+		}
+		c.Error(fmt.Errorf("GetLinkReminders failed: %v", err))
+		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, reminders)
+}
+
+// CancelLinkReminders
+// @Tags 링크
+// @Summary 링크 리마인더 취소
+// @Description 링크에 예약된 리마인더를 취소합니다.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param linkId path string true "링크 아이디"
+// @Success 204 "리마인더를 취소했습니다."
+// @Failure 401 {object} util.APIError "Authorization 헤더가 없을 때 반환합니다."
+// @Failure 404 {object} util.APIError "링크 아이디에 해당하는 링크가 없을 때 반환합니다."
+// @Failure 500 {object} util.APIError "서버 오류가 발생한 경우 반환합니다."
+// @Router /links/{linkId}/reminders [delete]
+func (h LinkHandler) CancelLinkReminders(c *gin.Context) {
+	currentUser, exists := c.Get("user")
+	if !exists {
+		// 401 Unauthorized
+		util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+		return
+	}
+
+	userId := currentUser.(*user.User).UserId
+
+	err := h.linkUsecase.CancelLinkReminders(userId, c.Param("linkId"))
+	if err != nil {
+		if err == util.ErrLinkNotFound {
+			util.SendError(c, http.StatusNotFound, util.CodeLinkNotFound)
+			return
+		}
+		c.Error(fmt.Errorf("CancelLinkReminders failed: %v", err))
+		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		return
+	}
+
+	// 204 No Content
+	c.Status(http.StatusNoContent)
+}
+
 // CreateLink
 // @Tags 링크
 // @Summary 링크 생성
@@ -196,22 +553,131 @@ func (h LinkHandler) GetLinks(c *gin.Context) {
 
 }
 
+// SemanticSearchLinks
+// @Tags 링크
+// @Summary 링크 시맨틱 검색
+// @Description 제목에 검색어가 없어도 의미가 비슷한 링크를 찾습니다. 링크의 제목, 태그, AI 요약, 추출한 본문을 임베딩해 검색어와의 유사도(score) 순으로 반환합니다.
+// @Description 링크 임베딩은 저장/수정할 때 만들고 주기 작업에서 채우므로, 방금 저장한 링크는 잠시 뒤에 검색될 수 있습니다.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param q query string true "검색어"
+// @Param limit query int false "결과 개수 (기본 20, 최대 50)"
+// @Success 200 {array} SemanticSearchLink "유사도 순으로 링크를 반환합니다."
+// @Failure 400 {object} util.APIError "검색어가 없을 때 반환합니다."
+// @Failure 401 {object} util.APIError "Authorization 헤더가 없을 때 반환합니다."
+// @Failure 500 {object} util.APIError "임베딩 또는 검색 과정에서 오류가 발생한 경우 반환합니다."
+// @Router /links/semantic-search [get]
+func (h LinkHandler) SemanticSearchLinks(c *gin.Context) {
+	currentUser, exists := c.Get("user")
+	if !exists {
+		// 401 Unauthorized
+		util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+		return
+	}
+
+	userId := currentUser.(*user.User).UserId
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		util.SendError(c, http.StatusBadRequest, util.CodeMissingParameter)
+		return
+	}
+
+	// 숫자가 아니면 기본 개수 사용
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	links, err := h.linkUsecase.SemanticSearch(userId, query, limit)
+	if err != nil {
+		c.Error(fmt.Errorf("SemanticSearchLinks failed: %v", err))
+		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+// AskLinks
+// @Tags 링크
+// @Summary 저장한 링크에 질문하기
+// @Description 저장한 링크의 본문을 근거로 질문에 답합니다. 답변의 [1], [2] 는 citations 의 순서와 같습니다.
+// @Description 관련 링크를 찾지 못하면 citations 가 빈 배열인 안내 답변을 반환합니다. 본문은 링크를 저장하거나 AI 요약을 만들 때 추출해 저장되며, 본문이 없는 링크는 AI 요약을 근거로 사용합니다.
+// @Description 사용자별 요청 수가 제한됩니다. (기본 분당 5회, 하루 50회) 제한을 넘으면 429 와 Retry-After 헤더를 반환합니다.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body AskLinksReq true "질문 (최대 500자)"
+// @Success 200 {object} AskLinksRes "답변과 근거 링크를 반환합니다."
+// @Failure 400 {object} util.APIError "질문이 없거나 너무 길 때 반환합니다."
+// @Failure 401 {object} util.APIError "Authorization 헤더가 없을 때 반환합니다."
+// @Failure 429 {object} util.APIError "요청 수 제한 또는 AI 기능 사용량 한도(일/월)를 넘었을 때 반환합니다."
+// @Failure 500 {object} util.APIError "검색 또는 답변 생성 과정에서 오류가 발생한 경우 반환합니다."
+// @Router /links/ask [post]
+func (h LinkHandler) AskLinks(c *gin.Context) {
+	currentUser, exists := c.Get("user")
+	if !exists {
+		// 401 Unauthorized
+		util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+		return
+	}
+
+	userId := currentUser.(*user.User).UserId
+
+	var req AskLinksReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.SendError(c, http.StatusBadRequest, util.CodeInvalidRequestBody)
+		return
+	}
+
+	question := strings.TrimSpace(req.Question)
+	if question == "" {
+		util.SendError(c, http.StatusBadRequest, util.CodeMissingParameter)
+		return
+	}
+	if len([]rune(question)) > MaxAskQuestionLength {
+		util.SendError(c, http.StatusBadRequest, util.CodeInvalidRequestBody)
+		return
+	}
+
+	result, err := h.linkUsecase.AskLinks(userId, question)
+	if err != nil {
+		if err == util.ErrAIQuotaExceeded {
+			util.SendError(c, http.StatusTooManyRequests, util.CodeAIQuotaExceeded)
+			return
+		}
+		c.Error(fmt.Errorf("AskLinks failed: %v", err))
+		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // GetLinkByLinkId godoc
 // @Tags 링크
 // @Summary 링크를 조회합니다.
 // @Description 링크 아이디를 통해 해당 링크를 조회합니다.
+// @Description `related` 에는 같은 태그, 같은 사이트, 같은 폴더, 비슷한 내용을 기준으로 고른 나의 다른 링크가 관련도 순으로 최대 5개 들어갑니다.
 // @Accept  json
 // @Produce  json
 // @Security ApiKeyAuth
 // @Param linkId path string true "링크 아이디"
-// @Success 200 {object} Link "링크 아이디 기반으로 링크를 반환합니다."
+// @Success 200 {object} LinkDetailRes "링크 아이디 기반으로 링크와 관련 링크를 반환합니다."
 // @Failure 401 {object} util.APIError "Authorization 헤더가 없을 때 반환합니다."
 // @Failure 404 {object} util.APIError "링크 아이디에 해당하는 링크가 없을 때 반환합니다."
 // @Router /links/{linkId} [get]
 func (h LinkHandler) GetLinkByLinkId(c *gin.Context) {
+	currentUser, exists := c.Get("user")
+	if !exists {
+		// 401 Unauthorized
+		util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+		return
+	}
+
+	userId := currentUser.(*user.User).UserId
 	linkId := c.Param("linkId")
 
-	link, err := h.linkUsecase.FindOneLinkByLinkId(linkId)
+	link, err := h.linkUsecase.FindLinkDetail(userId, linkId)
 	if err != nil {
 		// 404 Not Found
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
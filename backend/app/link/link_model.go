@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"joosum-backend/pkg/db"
+	"joosum-backend/pkg/vector"
 	"regexp"
 	"time"
 
@@ -27,6 +28,25 @@ type Link struct {
 	LastReadAt   time.Time `bson:"last_read_at" json:"lastReadAt"`
 	CreatedAt    time.Time `bson:"created_at" json:"createdAt"`
 	UpdatedAt    time.Time `bson:"updated_at" json:"updatedAt"`
+	// AI 요약. 요약을 생성하지 않은 링크는 null
+	Summary *LinkSummary `bson:"summary,omitempty" json:"summary"`
+}
+
+type LinkSummary struct {
+	Summary     string    `bson:"summary" json:"summary" example:"기획자가 프롬프트로 요구사항을 정리해 개발자와의 커뮤니케이션 비용을 줄이는 방법을 소개합니다."`
+	KeyPoints   []string  `bson:"key_points" json:"keyPoints" example:"요구사항을 프롬프트로 구조화,기능명세서 자동 보완"`
+	Language    string    `bson:"language" json:"language" example:"ko"`
+	Model       string    `bson:"model" json:"model" example:"gpt-4o-mini"`
+	GeneratedAt time.Time `bson:"generated_at" json:"generatedAt"`
+}
+
+// LinkContent 는 AI 기능에서 사용하는 링크 본문입니다. (linkContents 컬렉션)
+type LinkContent struct {
+	LinkId      string    `bson:"_id"`
+	UserId      string    `bson:"user_id"`
+	URL         string    `bson:"url"`
+	Text        string    `bson:"text"`
+	ExtractedAt time.Time `bson:"extracted_at"`
 }
 
 type LinkThumbnailRes struct {
@@ -46,6 +66,156 @@ type AITagRecommendationReq struct {
 type AITagRecommendationRes struct {
 	URL             string   `bson:"url" json:"url" example:"https://brunch.co.kr/@wine-ny/163"`
 	RecommendedTags []string `bson:"recommendedTags" json:"recommendedTags" example:"프롬프트,요구사항분석,기획자,개발자협업,기능명세서"`
+	// 추천 태그별로 사용자가 이미 가진 태그인지 표시 (기존 태그가 먼저 오도록 정렬)
+	Tags []AIRecommendedTag `bson:"tags" json:"tags"`
+	// 추천에 사용한 프롬프트 버전
+	PromptVersion string `bson:"promptVersion" json:"promptVersion" example:"v2"`
+}
+
+type AIRecommendedTag struct {
+	Name       string `bson:"name" json:"name" example:"프롬프트"`
+	IsExisting bool   `bson:"isExisting" json:"isExisting"`
+}
+
+type LinkBookSuggestion struct {
+	LinkBookId string  `json:"linkBookId" example:"649028fab77fe1a8a3b0815e"`
+	Title      string  `json:"title" example:"개발"`
+	Confidence float64 `json:"confidence" example:"0.86"`
+	Reason     string  `json:"reason" example:"React 관련 글이 모여 있는 폴더입니다."`
+}
+
+type LinkBookSuggestionRes struct {
+	LinkId      string               `json:"linkId"`
+	Suggestions []LinkBookSuggestion `json:"suggestions"`
+}
+
+type AutoFileLinksReq struct {
+	// 비우면 기본 폴더의 최근 링크를 분류합니다.
+	LinkIds []string `json:"linkIds"`
+}
+
+type AutoFileLinksRes struct {
+	// 폴더를 옮긴 링크. 각 기록의 autoFilingId 로 되돌릴 수 있습니다.
+	Filed []LinkAutoFiling `json:"filed"`
+	// 확신도가 낮아 옮기지 않은 링크의 추천 폴더
+	Suggested []LinkBookSuggestionRes `json:"suggested"`
+	// AI 사용량 한도에 도달했거나 오류로 분류하지 못한 링크 아이디. 다시 요청하면 이어서 분류합니다.
+	Skipped []string `json:"skipped"`
+}
+
+// LinkAutoFiling 은 AI 자동 분류로 링크를 옮긴 기록입니다. (linkAutoFilings 컬렉션)
+type LinkAutoFiling struct {
+	AutoFilingId     string     `bson:"_id" json:"autoFilingId" example:"AutoFiling-0767d6af-a802-469c-9505-5ca91e03b354"`
+	UserId           string     `bson:"user_id" json:"userId"`
+	LinkId           string     `bson:"link_id" json:"linkId"`
+	FromLinkBookId   string     `bson:"from_link_book_id" json:"fromLinkBookId"`
+	FromLinkBookName string     `bson:"from_link_book_name" json:"fromLinkBookName"`
+	ToLinkBookId     string     `bson:"to_link_book_id" json:"toLinkBookId"`
+	ToLinkBookName   string     `bson:"to_link_book_name" json:"toLinkBookName"`
+	Confidence       float64    `bson:"confidence" json:"confidence"`
+	CreatedAt        time.Time  `bson:"created_at" json:"createdAt"`
+	UndoneAt         *time.Time `bson:"undone_at" json:"undoneAt"`
+}
+
+// 링크 리마인더 상태
+const (
+	ReminderScheduled = "scheduled"
+	ReminderSent      = "sent"
+	ReminderCancelled = "cancelled"
+)
+
+// 링크 리마인더 취소 사유
+const (
+	// 알림 시각 전에 링크를 읽음
+	ReminderCancelRead = "read"
+	// 링크(또는 폴더, 계정의 모든 링크)를 삭제함
+	ReminderCancelDeleted = "deleted"
+	// 같은 링크에 새 리마인더를 등록함
+	ReminderCancelReplaced = "replaced"
+	// 사용자가 직접 취소함
+	ReminderCancelUser = "user"
+)
+
+// LinkReminder 는 "나중에 읽기" 알림 예약입니다. (linkReminders 컬렉션)
+// 링크 하나에 예약된 리마인더는 하나이며, 알림 시각이 되면 리마인더 작업이 푸시를 보내고 reminder 알림을 저장합니다.
+type LinkReminder struct {
+	ReminderId string    `bson:"_id" json:"reminderId" example:"Reminder-5c0f8f3e-8a51-4a0b-9d0c-3f3f2b1e7a10"`
+	LinkId     string    `bson:"link_id" json:"linkId"`
+	UserId     string    `bson:"user_id" json:"userId"`
+	RemindAt   time.Time `bson:"remind_at" json:"remindAt"`
+	// 시각을 preset 으로 정한 경우 (tonight, weekend, nextWeek)
+	Preset       string     `bson:"preset,omitempty" json:"preset,omitempty" example:"tonight"`
+	Status       string     `bson:"status" json:"status" example:"scheduled"`
+	CancelReason string     `bson:"cancel_reason,omitempty" json:"cancelReason,omitempty" example:"read"`
+	CreatedAt    time.Time  `bson:"created_at" json:"createdAt"`
+	SentAt       *time.Time `bson:"sent_at,omitempty" json:"sentAt,omitempty"`
+	CancelledAt  *time.Time `bson:"cancelled_at,omitempty" json:"cancelledAt,omitempty"`
+}
+
+type CreateLinkReminderReq struct {
+	// 알림 받을 시각 (RFC3339). preset 과 둘 중 하나만 보냅니다.
+	RemindAt *time.Time `json:"remindAt" example:"2026-10-20T21:00:00+09:00"`
+	// tonight(오늘 21시), weekend(토요일 10시), nextWeek(다음 주 월요일 9시). 알림 설정의 시간대 기준
+	Preset string `json:"preset" example:"tonight"`
+}
+
+// LinkEmbedding 은 시맨틱 검색에 사용하는 링크 벡터입니다. (linkEmbeddings 컬렉션)
+type LinkEmbedding struct {
+	LinkId string    `bson:"_id"`
+	UserId string    `bson:"user_id"`
+	Model  string    `bson:"model"`
+	Vector []float32 `bson:"vector"`
+	// 임베딩한 텍스트의 해시. 제목/태그/요약이 바뀌면 다시 임베딩합니다.
+	TextHash  string    `bson:"text_hash"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+type SemanticSearchLink struct {
+	Link
+	// 검색어와의 유사도 (높을수록 비슷함)
+	Score float64 `json:"score" example:"0.82"`
+}
+
+type RelatedLink struct {
+	Link
+	// 관련도 (0~1)
+	Score float64 `json:"score" example:"0.64"`
+	// 관련 있다고 판단한 근거 (tag: 같은 태그, domain: 같은 사이트, link_book: 같은 폴더, content: 비슷한 내용)
+	Reasons []string `json:"reasons" example:"tag,content"`
+}
+
+type LinkDetailRes struct {
+	Link
+	// 같은 사용자의 관련 링크 (최대 5개)
+	Related []RelatedLink `json:"related"`
+}
+
+type AskLinksReq struct {
+	Question string `json:"question" example:"Go 제네릭에 대해 저장한 글들은 뭐라고 했지?"`
+}
+
+type AskLinksRes struct {
+	Question string `json:"question"`
+	// 답변 본문. 근거가 된 링크는 [1], [2] 처럼 citations 의 순서로 표시합니다.
+	Answer    string        `json:"answer"`
+	Citations []AskCitation `json:"citations"`
+}
+
+type AskCitation struct {
+	LinkId string `json:"linkId"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+}
+
+// AITagCache 는 AI 태그 추천 결과 캐시입니다. (aiTagCache 컬렉션, 7일 후 자동 삭제)
+type AITagCache struct {
+	// 정규화한 URL, 프롬프트 버전과 템플릿 해시, 모델로 만든 해시 (사용자와 무관)
+	Key           string    `bson:"_id"`
+	URL           string    `bson:"url"`
+	PromptVersion string    `bson:"prompt_version"`
+	Model         string    `bson:"model"`
+	Tags          []string  `bson:"tags"`
+	CreatedAt     time.Time `bson:"created_at"`
 }
 
 type LinkModel struct {
@@ -93,7 +263,8 @@ func (LinkModel) Get9LinksByUserId(userId string) ([]*Link, error) {
 
 	var links []*Link
 
-	cursor, err := db.LinkCollection.Find(ctx, bson.M{"user_id": userId}, options.Find().SetLimit(9))
+	opts := options.Find().SetSort(bson.M{"created_at": -1}).SetLimit(9)
+	cursor, err := db.LinkCollection.Find(ctx, bson.M{"user_id": userId}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -356,6 +527,149 @@ func (LinkModel) UpdateLinkBookIdAndTitleByLinkId(linkId string, linkBookId stri
 	return err
 }
 
+// MoveLinksToLinkBook 은 링크북의 모든 링크를 다른 링크북으로 옮기고 링크북 이름도 함께 변경합니다.
+func (LinkModel) MoveLinksToLinkBook(userId string, fromLinkBookId string, toLinkBookId string, toLinkBookName string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userId, "link_book_id": fromLinkBookId}
+	update := bson.M{"$set": bson.M{"link_book_id": toLinkBookId, "link_book_name": toLinkBookName}}
+
+	result, err := db.LinkCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// GetLinkBookNameDrifts 는 userIds 사용자의 링크 중 저장된 link_book_name 이 링크북 제목과 다른 링크들을 링크북별로 집계합니다.
+// 전체 사용자를 한 번에 집계하지 않도록 사용자 몇 명씩 나누어 부릅니다.
+func (LinkModel) GetLinkBookNameDrifts(userIds []string) ([]LinkBookNameDrift, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": bson.M{"$in": userIds}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         db.LinkBookCollection.Name(),
+			"localField":   "link_book_id",
+			"foreignField": "_id",
+			"as":           "link_book",
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"link_book_title": bson.M{"$arrayElemAt": bson.A{"$link_book.title", 0}},
+		}}},
+		{{Key: "$match", Value: bson.M{
+			"$expr": bson.M{"$ne": bson.A{"$link_book_name", bson.M{"$ifNull": bson.A{"$link_book_title", nil}}}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$link_book_id",
+			"title":         bson.M{"$first": "$link_book_title"},
+			"drifted_links": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.M{"drifted_links": db.Desc}}},
+	}
+
+	cur, err := db.LinkCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	drifts := []LinkBookNameDrift{}
+	if err := cur.All(ctx, &drifts); err != nil {
+		return nil, err
+	}
+
+	return drifts, nil
+}
+
+// SyncLinkBookName 은 링크북의 링크들에 저장된 링크북 이름을 현재 제목으로 맞춥니다.
+func (LinkModel) SyncLinkBookName(linkBookId string, linkBookName string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"link_book_id": linkBookId, "link_book_name": bson.M{"$ne": linkBookName}}
+	update := bson.M{"$set": bson.M{"link_book_name": linkBookName}}
+
+	result, err := db.LinkCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func (LinkModel) UpdateSummaryByLinkId(linkId string, summary *LinkSummary) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.LinkCollection.UpdateOne(ctx, bson.M{"link_id": linkId}, bson.M{"$set": bson.M{"summary": summary}})
+
+	return err
+}
+
+func (LinkModel) UpsertLinkContent(content LinkContent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := db.LinkContentCollection.ReplaceOne(ctx, bson.M{"_id": content.LinkId}, content, opts)
+
+	return err
+}
+
+func (LinkModel) GetLinkContent(linkId string) (*LinkContent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var content LinkContent
+	err := db.LinkContentCollection.FindOne(ctx, bson.M{"_id": linkId}).Decode(&content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &content, nil
+}
+
+func (LinkModel) GetLinkIdsByLinkBookId(userId string, linkBookId string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	values, err := db.LinkCollection.Distinct(ctx, "link_id", bson.M{"user_id": userId, "link_book_id": linkBookId})
+	if err != nil {
+		return nil, err
+	}
+
+	linkIds := make([]string, 0, len(values))
+	for _, v := range values {
+		if linkId, ok := v.(string); ok {
+			linkIds = append(linkIds, linkId)
+		}
+	}
+
+	return linkIds, nil
+}
+
+func (LinkModel) DeleteLinkContentsByLinkIds(linkIds []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.LinkContentCollection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": linkIds}})
+
+	return err
+}
+
+func (LinkModel) DeleteLinkContentsByUserId(userId string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.LinkContentCollection.DeleteMany(ctx, bson.M{"user_id": userId})
+
+	return err
+}
+
 func (LinkModel) UpdateTitleAndUrlByLinkId(linkId string, url string, title string, thumbnailURL string, tags []string) (*Link, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -400,3 +714,481 @@ func (LinkModel) UpdateTitleAndUrlByLinkId(linkId string, url string, title stri
 
 	return link, nil
 }
+
+// MoveLinkIfInLinkBook 은 링크가 아직 fromLinkBookId 에 있을 때만 toLinkBookId 로 옮깁니다.
+// 그 사이 사용자가 링크를 직접 옮겼다면 false 를 반환합니다.
+func (LinkModel) MoveLinkIfInLinkBook(linkId string, fromLinkBookId string, toLinkBookId string, toLinkBookName string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"link_id": linkId, "link_book_id": fromLinkBookId}
+	update := bson.M{"$set": bson.M{"link_book_id": toLinkBookId, "link_book_name": toLinkBookName}}
+
+	result, err := db.LinkCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// GetRecentLinkTitlesByLinkBook 은 링크북별 최근 링크 제목을 최대 limit 개씩 반환합니다.
+func (LinkModel) GetRecentLinkTitlesByLinkBook(userId string, limit int) (map[string][]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 링크북마다 (link_book_id, created_at) 인덱스로 최근 링크 limit 개만 읽음
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userId}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": db.LinkCollection.Name(),
+			"let":  bson.M{"linkBookId": "$_id"},
+			"pipeline": mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"$expr": bson.M{"$eq": bson.A{"$link_book_id", "$$linkBookId"}}}}},
+				{{Key: "$sort", Value: bson.M{"created_at": -1}}},
+				{{Key: "$limit", Value: limit}},
+				{{Key: "$project", Value: bson.M{"_id": 0, "title": 1}}},
+			},
+			"as": "links",
+		}}},
+		{{Key: "$project", Value: bson.M{"titles": "$links.title"}}},
+	}
+
+	cur, err := db.LinkBookCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var results []struct {
+		LinkBookId string   `bson:"_id"`
+		Titles     []string `bson:"titles"`
+	}
+	if err := cur.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	titles := make(map[string][]string, len(results))
+	for _, r := range results {
+		titles[r.LinkBookId] = r.Titles
+	}
+
+	return titles, nil
+}
+
+func (LinkModel) CreateLinkAutoFiling(filing LinkAutoFiling) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.LinkAutoFilingCollection.InsertOne(ctx, filing)
+
+	return err
+}
+
+func (LinkModel) GetLinkAutoFiling(autoFilingId string) (*LinkAutoFiling, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var filing LinkAutoFiling
+	err := db.LinkAutoFilingCollection.FindOne(ctx, bson.M{"_id": autoFilingId}).Decode(&filing)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filing, nil
+}
+
+func (LinkModel) GetLinkAutoFilingsByUserId(userId string, limit int64) ([]LinkAutoFiling, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.M{"created_at": -1}).SetLimit(limit)
+	cur, err := db.LinkAutoFilingCollection.Find(ctx, bson.M{"user_id": userId}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	filings := []LinkAutoFiling{}
+	if err := cur.All(ctx, &filings); err != nil {
+		return nil, err
+	}
+
+	return filings, nil
+}
+
+// MarkLinkAutoFilingUndone 은 아직 되돌리지 않은 기록만 되돌림 처리합니다.
+func (LinkModel) MarkLinkAutoFilingUndone(autoFilingId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": autoFilingId, "undone_at": nil}
+	update := bson.M{"$set": bson.M{"undone_at": time.Now()}}
+
+	result, err := db.LinkAutoFilingCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+func (LinkModel) CreateLinkReminder(reminder LinkReminder) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.LinkReminderCollection.InsertOne(ctx, reminder)
+
+	return err
+}
+
+// GetLinkReminders 는 링크에 예약된(아직 보내지 않은) 리마인더를 반환합니다.
+func (LinkModel) GetLinkReminders(userId string, linkId string) ([]LinkReminder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userId, "link_id": linkId, "status": ReminderScheduled}
+	opts := options.Find().SetSort(bson.M{"remind_at": 1})
+
+	cur, err := db.LinkReminderCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	reminders := []LinkReminder{}
+	if err := cur.All(ctx, &reminders); err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// GetDueLinkReminders 는 알림 시각이 now 이전인 예약된 리마인더를 알림 시각 순으로 최대 limit 개 반환합니다.
+func (LinkModel) GetDueLinkReminders(now time.Time, limit int64) ([]LinkReminder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": ReminderScheduled, "remind_at": bson.M{"$lte": now}}
+	opts := options.Find().SetSort(bson.M{"remind_at": 1}).SetLimit(limit)
+
+	cur, err := db.LinkReminderCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	reminders := []LinkReminder{}
+	if err := cur.All(ctx, &reminders); err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// MarkLinkReminderSent 는 예약된 리마인더만 보냄 처리합니다.
+func (LinkModel) MarkLinkReminderSent(reminderId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": reminderId, "status": ReminderScheduled}
+	update := bson.M{"$set": bson.M{"status": ReminderSent, "sent_at": time.Now()}}
+
+	result, err := db.LinkReminderCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// CancelLinkReminder 는 예약된 리마인더 하나를 취소합니다.
+func (LinkModel) CancelLinkReminder(reminderId string, reason string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": reminderId, "status": ReminderScheduled}
+	update := bson.M{"$set": bson.M{"status": ReminderCancelled, "cancel_reason": reason, "cancelled_at": time.Now()}}
+
+	result, err := db.LinkReminderCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// CancelLinkReminders 는 링크들에 예약된 리마인더를 모두 취소하고, 취소한 수를 반환합니다.
+func (LinkModel) CancelLinkReminders(linkIds []string, reason string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"link_id": bson.M{"$in": linkIds}, "status": ReminderScheduled}
+	update := bson.M{"$set": bson.M{"status": ReminderCancelled, "cancel_reason": reason, "cancelled_at": time.Now()}}
+
+	result, err := db.LinkReminderCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// CancelLinkRemindersByUserId 는 사용자에게 예약된 리마인더를 모두 취소합니다.
+func (LinkModel) CancelLinkRemindersByUserId(userId string, reason string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userId, "status": ReminderScheduled}
+	update := bson.M{"$set": bson.M{"status": ReminderCancelled, "cancel_reason": reason, "cancelled_at": time.Now()}}
+
+	result, err := db.LinkReminderCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// GetLinkEmbeddingHashes 는 사용자의 링크별 임베딩 텍스트 해시를 반환합니다.
+func (LinkModel) GetLinkEmbeddingHashes(userId string, model string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetProjection(bson.M{"text_hash": 1})
+	cur, err := db.LinkEmbeddingCollection.Find(ctx, bson.M{"user_id": userId, "model": model}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var results []LinkEmbedding
+	if err := cur.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	hashes := make(map[string]string, len(results))
+	for _, r := range results {
+		hashes[r.LinkId] = r.TextHash
+	}
+
+	return hashes, nil
+}
+
+func (LinkModel) GetLinkEmbeddingsByUserId(userId string, model string) ([]LinkEmbedding, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cur, err := db.LinkEmbeddingCollection.Find(ctx, bson.M{"user_id": userId, "model": model})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var embeddings []LinkEmbedding
+	if err := cur.All(ctx, &embeddings); err != nil {
+		return nil, err
+	}
+
+	return embeddings, nil
+}
+
+// GetLinkEmbeddingsByLinkIds 는 사용자의 링크 중 linkIds 링크의 임베딩만 반환합니다.
+func (LinkModel) GetLinkEmbeddingsByLinkIds(userId string, model string, linkIds []string) ([]LinkEmbedding, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cur, err := db.LinkEmbeddingCollection.Find(ctx, bson.M{"_id": bson.M{"$in": linkIds}, "user_id": userId, "model": model})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var embeddings []LinkEmbedding
+	if err := cur.All(ctx, &embeddings); err != nil {
+		return nil, err
+	}
+
+	return embeddings, nil
+}
+
+func (LinkModel) UpsertLinkEmbedding(embedding LinkEmbedding) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := db.LinkEmbeddingCollection.ReplaceOne(ctx, bson.M{"_id": embedding.LinkId}, embedding, opts)
+
+	return err
+}
+
+// VectorSearchLinkEmbeddings 는 Atlas Vector Search($vectorSearch)로 사용자의 링크 벡터를 검색합니다.
+func (LinkModel) VectorSearchLinkEmbeddings(indexName string, userId string, model string, query []float32, limit int) ([]vector.Match, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$vectorSearch", Value: bson.M{
+			"index":         indexName,
+			"path":          "vector",
+			"queryVector":   query,
+			"numCandidates": limit * 10,
+			"limit":         limit,
+			"filter":        bson.M{"user_id": userId, "model": model},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"score": bson.M{"$meta": "vectorSearchScore"},
+		}}},
+	}
+
+	cur, err := db.LinkEmbeddingCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var results []struct {
+		LinkId string  `bson:"_id"`
+		Score  float64 `bson:"score"`
+	}
+	if err := cur.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	matches := make([]vector.Match, 0, len(results))
+	for _, r := range results {
+		matches = append(matches, vector.Match{ID: r.LinkId, Score: r.Score})
+	}
+
+	return matches, nil
+}
+
+func (LinkModel) GetLinksByLinkIds(userId string, linkIds []string) ([]*Link, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cur, err := db.LinkCollection.Find(ctx, bson.M{"user_id": userId, "link_id": bson.M{"$in": linkIds}})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var links []*Link
+	if err := cur.All(ctx, &links); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// GetRelatedLinkCandidates 는 태그, 링크북, 사이트(도메인) 중 하나라도 같은 사용자의 링크를 최근 순으로 최대 limit 개 반환합니다.
+func (LinkModel) GetRelatedLinkCandidates(userId string, tags []string, linkBookIds []string, domains []string, limit int64) ([]*Link, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var conditions bson.A
+	if len(tags) > 0 {
+		conditions = append(conditions, bson.M{"tags": bson.M{"$in": tags}})
+	}
+	if len(linkBookIds) > 0 {
+		conditions = append(conditions, bson.M{"link_book_id": bson.M{"$in": linkBookIds}})
+	}
+	for _, domain := range domains {
+		pattern := `^https?://(www\.)?` + regexp.QuoteMeta(domain) + `([:/?#]|$)`
+		conditions = append(conditions, bson.M{"url": bson.M{"$regex": pattern, "$options": "i"}})
+	}
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": -1}).SetLimit(limit)
+	cur, err := db.LinkCollection.Find(ctx, bson.M{"user_id": userId, "$or": conditions}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var links []*Link
+	if err := cur.All(ctx, &links); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// DeleteLinkDerivedData 는 링크에서 파생된 본문과 임베딩을 삭제하고, 예약된 리마인더를 취소합니다.
+func (m LinkModel) DeleteLinkDerivedData(linkIds []string) error {
+	if _, err := m.CancelLinkReminders(linkIds, ReminderCancelDeleted); err != nil {
+		return err
+	}
+
+	if err := m.DeleteLinkContentsByLinkIds(linkIds); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.LinkEmbeddingCollection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": linkIds}})
+
+	return err
+}
+
+// DeleteLinkDerivedDataByUserId 는 사용자의 모든 링크 본문과 임베딩을 삭제하고, 예약된 리마인더를 취소합니다.
+func (m LinkModel) DeleteLinkDerivedDataByUserId(userId string) error {
+	if _, err := m.CancelLinkRemindersByUserId(userId, ReminderCancelDeleted); err != nil {
+		return err
+	}
+
+	if err := m.DeleteLinkContentsByUserId(userId); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.LinkEmbeddingCollection.DeleteMany(ctx, bson.M{"user_id": userId})
+
+	return err
+}
+
+func (LinkModel) GetLinkContentsByLinkIds(linkIds []string) ([]LinkContent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cur, err := db.LinkContentCollection.Find(ctx, bson.M{"_id": bson.M{"$in": linkIds}})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var contents []LinkContent
+	if err := cur.All(ctx, &contents); err != nil {
+		return nil, err
+	}
+
+	return contents, nil
+}
+
+func (LinkModel) GetAITagCache(key string) (*AITagCache, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var cache AITagCache
+	err := db.AITagCacheCollection.FindOne(ctx, bson.M{"_id": key}).Decode(&cache)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cache, nil
+}
+
+func (LinkModel) UpsertAITagCache(cache AITagCache) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := db.AITagCacheCollection.ReplaceOne(ctx, bson.M{"_id": cache.Key}, cache, opts)
+
+	return err
+}
@@ -0,0 +1,319 @@
+package link
+
+import (
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+
+	"joosum-backend/pkg/vector"
+)
+
+const (
+	// 링크 상세의 관련 링크 개수
+	maxRelatedLinks = 5
+	// 메인 페이지 관련 링크 섹션 개수와 기준으로 삼는 최근 링크 수
+	mainPageRelatedLinks = 9
+	mainPageRelatedSeeds = 3
+	// 태그/폴더/사이트가 같은 링크, 내용이 비슷한 링크를 각각 최대 이 수만큼 후보로 불러옴
+	maxRelatedCandidates = 100
+	// 이 점수보다 낮은 링크는 관련 링크로 보지 않음 (같은 폴더만으로는 관련 링크가 되지 않도록)
+	minRelatedScore = 0.2
+)
+
+// 관련도 가중치
+const (
+	relatedTagWeight      = 0.4
+	relatedDomainWeight   = 0.2
+	relatedLinkBookWeight = 0.15
+	relatedContentWeight  = 0.5
+)
+
+// 관련 근거
+const (
+	RelatedReasonTag      = "tag"
+	RelatedReasonDomain   = "domain"
+	RelatedReasonLinkBook = "link_book"
+	RelatedReasonContent  = "content"
+)
+
+// relatedLinkIndex 는 관련 링크 계산에 쓰는 후보 링크(최신순)와 기준/후보 링크의 임베딩입니다
+type relatedLinkIndex struct {
+	links   []*Link
+	vectors map[string][]float32
+}
+
+// FindLinkDetail 링크와 같은 사용자의 관련 링크를 함께 반환합니다
+// 다른 사용자의 링크를 조회하면 관련 링크는 비워서 반환합니다
+func (u LinkUsecase) FindLinkDetail(userId string, linkId string) (*LinkDetailRes, error) {
+	link, err := u.FindOneLinkByLinkId(linkId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &LinkDetailRes{Link: *link, Related: []RelatedLink{}}
+	if link.UserID != userId {
+		return res, nil
+	}
+
+	bases := []*Link{link}
+	index, err := u.loadRelatedLinkIndex(userId, bases)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Related = index.related(bases, map[string]bool{link.LinkId: true}, maxRelatedLinks)
+
+	return res, nil
+}
+
+// GetRelatedLinksForMainPage 메인 페이지용으로 최근 저장한 링크와 관련된 링크를 반환합니다
+// recent 는 메인 페이지의 최근 링크 목록(최신순)이며, 앞의 몇 개를 기준 링크로 쓰고 목록에 이미 있는 링크는 제외합니다
+func (u LinkUsecase) GetRelatedLinksForMainPage(userId string, recent []*Link) ([]RelatedLink, error) {
+	seeds := recent
+	if len(seeds) > mainPageRelatedSeeds {
+		seeds = seeds[:mainPageRelatedSeeds]
+	}
+	if len(seeds) == 0 {
+		return []RelatedLink{}, nil
+	}
+
+	index, err := u.loadRelatedLinkIndex(userId, seeds)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[string]bool, len(recent))
+	for _, link := range recent {
+		excluded[link.LinkId] = true
+	}
+
+	return index.related(seeds, excluded, mainPageRelatedLinks), nil
+}
+
+// loadRelatedLinkIndex 기준 링크와 관련 있을 만한 후보 링크와 그 임베딩만 불러옵니다
+// 후보는 태그/폴더/사이트가 같은 최근 링크와, 기준 링크의 벡터로 검색한 내용이 비슷한 링크입니다
+// 임베딩 제공자를 만들 수 없거나 임베딩을 불러오지 못하면 내용 유사도 없이 태그/사이트/폴더로만 계산합니다
+func (u LinkUsecase) loadRelatedLinkIndex(userId string, bases []*Link) (*relatedLinkIndex, error) {
+	var tags, linkBookIds, domains []string
+	for _, base := range bases {
+		tags = append(tags, base.Tags...)
+		linkBookIds = append(linkBookIds, base.LinkBookId)
+		if domain := linkDomain(base.URL); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+
+	candidates, err := u.linkModel.GetRelatedLinkCandidates(userId, tags, linkBookIds, domains, maxRelatedCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &relatedLinkIndex{vectors: map[string][]float32{}}
+
+	similar, err := u.loadRelatedVectors(userId, bases, candidates, index)
+	if err != nil {
+		return nil, err
+	}
+	candidates = append(candidates, similar...)
+
+	err = u.resolveLinkBookNames(userId, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	// 관련도가 같으면 최근 링크가 앞에 오도록 최신순으로 정렬
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].CreatedAt.After(candidates[j].CreatedAt)
+	})
+	index.links = candidates
+
+	return index, nil
+}
+
+// loadRelatedVectors 기준 링크의 벡터로 내용이 비슷한 링크를 찾아 후보에 없던 링크를 반환하고, 기준/후보 링크의 벡터를 index 에 채웁니다
+// 관련 링크 계산에서는 임베딩 API 를 호출하지 않고 저장된 벡터만 사용합니다
+func (u LinkUsecase) loadRelatedVectors(userId string, bases []*Link, candidates []*Link, index *relatedLinkIndex) ([]*Link, error) {
+	provider, err := u.getEmbeddingProvider()
+	if err != nil {
+		return nil, nil
+	}
+	model := provider.Model()
+
+	baseIds := make([]string, 0, len(bases))
+	for _, base := range bases {
+		baseIds = append(baseIds, base.LinkId)
+	}
+	baseEmbeddings, err := u.linkModel.GetLinkEmbeddingsByLinkIds(userId, model, baseIds)
+	if err != nil {
+		log.Printf("[관련 링크] 임베딩 조회 실패 (userId=%s): %v", userId, err)
+		return nil, nil
+	}
+
+	known := make(map[string]bool, len(candidates)+len(bases))
+	for _, link := range candidates {
+		known[link.LinkId] = true
+	}
+	for _, id := range baseIds {
+		known[id] = true
+	}
+
+	var similarIds []string
+	for _, e := range baseEmbeddings {
+		index.vectors[e.LinkId] = e.Vector
+
+		matches, err := u.searchLinkEmbeddings(userId, model, e.Vector, maxRelatedCandidates)
+		if err != nil {
+			log.Printf("[관련 링크] 벡터 검색 실패 (linkId=%s): %v", e.LinkId, err)
+			continue
+		}
+		for _, m := range matches {
+			if !known[m.ID] {
+				known[m.ID] = true
+				similarIds = append(similarIds, m.ID)
+			}
+		}
+	}
+
+	var similar []*Link
+	if len(similarIds) > 0 {
+		similar, err = u.linkModel.GetLinksByLinkIds(userId, similarIds)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	candidateIds := make([]string, 0, len(candidates)+len(similar))
+	for _, link := range candidates {
+		candidateIds = append(candidateIds, link.LinkId)
+	}
+	for _, link := range similar {
+		candidateIds = append(candidateIds, link.LinkId)
+	}
+	if len(candidateIds) == 0 {
+		return similar, nil
+	}
+
+	embeddings, err := u.linkModel.GetLinkEmbeddingsByLinkIds(userId, model, candidateIds)
+	if err != nil {
+		log.Printf("[관련 링크] 임베딩 조회 실패 (userId=%s): %v", userId, err)
+		return similar, nil
+	}
+	for _, e := range embeddings {
+		index.vectors[e.LinkId] = e.Vector
+	}
+
+	return similar, nil
+}
+
+// related 기준 링크들과 관련도가 높은 링크를 최대 limit 개 반환합니다
+// 기준 링크가 여러 개면 가장 높은 관련도를 사용합니다
+func (idx *relatedLinkIndex) related(bases []*Link, exclude map[string]bool, limit int) []RelatedLink {
+	results := []RelatedLink{}
+	for _, candidate := range idx.links {
+		if exclude[candidate.LinkId] {
+			continue
+		}
+
+		var best float64
+		var bestReasons []string
+		for _, base := range bases {
+			score, reasons := idx.score(base, candidate)
+			if score > best {
+				best, bestReasons = score, reasons
+			}
+		}
+
+		if best < minRelatedScore {
+			continue
+		}
+		results = append(results, RelatedLink{Link: *candidate, Score: best, Reasons: bestReasons})
+	}
+
+	// 관련도가 같으면 최근 링크 우선 (idx.links 가 최신순)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
+
+// score 두 링크의 관련도(0~1)와 근거를 계산합니다
+func (idx *relatedLinkIndex) score(base, candidate *Link) (float64, []string) {
+	var score float64
+	reasons := []string{}
+
+	if shared := tagOverlap(base.Tags, candidate.Tags); shared > 0 {
+		score += relatedTagWeight * shared
+		reasons = append(reasons, RelatedReasonTag)
+	}
+
+	if domain := linkDomain(base.URL); domain != "" && domain == linkDomain(candidate.URL) {
+		score += relatedDomainWeight
+		reasons = append(reasons, RelatedReasonDomain)
+	}
+
+	if base.LinkBookId == candidate.LinkBookId {
+		score += relatedLinkBookWeight
+		reasons = append(reasons, RelatedReasonLinkBook)
+	}
+
+	baseVector, ok1 := idx.vectors[base.LinkId]
+	candidateVector, ok2 := idx.vectors[candidate.LinkId]
+	if ok1 && ok2 {
+		if similarity := vector.CosineSimilarity(baseVector, candidateVector); similarity > 0 {
+			score += relatedContentWeight * similarity
+			reasons = append(reasons, RelatedReasonContent)
+		}
+	}
+
+	if score > 1 {
+		score = 1
+	}
+
+	return score, reasons
+}
+
+// tagOverlap 두 태그 목록의 자카드 유사도(0~1)를 반환합니다. 대소문자와 공백은 무시합니다
+func tagOverlap(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	set := make(map[string]bool, len(a))
+	for _, tag := range a {
+		set[normalizeTagKey(tag)] = true
+	}
+
+	union := len(set)
+	shared := 0
+	seen := make(map[string]bool, len(b))
+	for _, tag := range b {
+		key := normalizeTagKey(tag)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if set[key] {
+			shared++
+		} else {
+			union++
+		}
+	}
+
+	return float64(shared) / float64(union)
+}
+
+// linkDomain URL 의 호스트를 www. 없이 반환합니다
+func linkDomain(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
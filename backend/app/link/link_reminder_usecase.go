@@ -0,0 +1,140 @@
+package link
+
+import (
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"joosum-backend/app/setting"
+	"joosum-backend/pkg/util"
+)
+
+// 리마인더 시각 preset. 알림 설정의 시간대 기준입니다.
+const (
+	// 오늘 21시 (21시가 지났으면 내일 21시)
+	ReminderPresetTonight = "tonight"
+	// 돌아오는 토요일 10시 (토요일 10시 전이면 오늘, 주말이 지나고 있으면 다음 토요일)
+	ReminderPresetWeekend = "weekend"
+	// 다음 주 월요일 9시
+	ReminderPresetNextWeek = "nextWeek"
+)
+
+const (
+	reminderTonightHour  = 21
+	reminderWeekendHour  = 10
+	reminderNextWeekHour = 9
+	// 이 기간보다 먼 시각에는 예약할 수 없습니다.
+	maxReminderDays = 365
+)
+
+// CreateLinkReminder 링크에 "나중에 읽기" 알림을 예약합니다
+// 링크에 이미 예약된 리마인더가 있으면 취소하고 새로 예약합니다
+func (u LinkUsecase) CreateLinkReminder(userId string, linkId string, req CreateLinkReminderReq) (*LinkReminder, error) {
+	if _, err := u.getOwnedLink(userId, linkId); err != nil {
+		return nil, err
+	}
+
+	agree, err := setting.SettingModel{}.GetNotificationAgree(userId)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+		agree = &setting.NotificationAgree{UserId: userId}
+	}
+
+	remindAt, err := resolveRemindAt(req, time.Now(), agree.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.linkModel.CancelLinkReminders([]string{linkId}, ReminderCancelReplaced); err != nil {
+		return nil, err
+	}
+
+	reminder := LinkReminder{
+		ReminderId: util.CreateId("Reminder"),
+		LinkId:     linkId,
+		UserId:     userId,
+		RemindAt:   remindAt,
+		Preset:     req.Preset,
+		Status:     ReminderScheduled,
+		CreatedAt:  time.Now(),
+	}
+	if err := u.linkModel.CreateLinkReminder(reminder); err != nil {
+		return nil, err
+	}
+
+	return &reminder, nil
+}
+
+// GetLinkReminders 링크에 예약된 리마인더를 반환합니다
+func (u LinkUsecase) GetLinkReminders(userId string, linkId string) ([]LinkReminder, error) {
+	if _, err := u.getOwnedLink(userId, linkId); err != nil {
+		return nil, err
+	}
+
+	return u.linkModel.GetLinkReminders(userId, linkId)
+}
+
+// CancelLinkReminders 링크에 예약된 리마인더를 취소합니다
+func (u LinkUsecase) CancelLinkReminders(userId string, linkId string) error {
+	if _, err := u.getOwnedLink(userId, linkId); err != nil {
+		return err
+	}
+
+	_, err := u.linkModel.CancelLinkReminders([]string{linkId}, ReminderCancelUser)
+	return err
+}
+
+// cancelRemindersOnRead 읽은 링크의 리마인더를 취소합니다. 실패해도 읽음 처리는 성공으로 봅니다
+func (u LinkUsecase) cancelRemindersOnRead(linkId string) {
+	if _, err := u.linkModel.CancelLinkReminders([]string{linkId}, ReminderCancelRead); err != nil {
+		log.Printf("[링크 리마인더] 읽은 링크의 리마인더 취소 실패 (linkId=%s): %v", linkId, err)
+	}
+}
+
+// resolveRemindAt 요청의 시각(remindAt) 또는 preset 으로 알림 시각을 정합니다
+// 둘 중 하나만 있어야 하며, 지난 시각이나 maxReminderDays 이후는 ErrInvalidReminderTime 입니다
+func resolveRemindAt(req CreateLinkReminderReq, now time.Time, loc *time.Location) (time.Time, error) {
+	if (req.RemindAt == nil) == (req.Preset == "") {
+		return time.Time{}, util.ErrInvalidReminderTime
+	}
+
+	var remindAt time.Time
+	if req.RemindAt != nil {
+		remindAt = *req.RemindAt
+	} else {
+		local := now.In(loc)
+		at := func(days int, hour int) time.Time {
+			return time.Date(local.Year(), local.Month(), local.Day()+days, hour, 0, 0, 0, loc)
+		}
+
+		switch req.Preset {
+		case ReminderPresetTonight:
+			remindAt = at(0, reminderTonightHour)
+			if !remindAt.After(now) {
+				remindAt = at(1, reminderTonightHour)
+			}
+		case ReminderPresetWeekend:
+			days := (int(time.Saturday) - int(local.Weekday()) + 7) % 7
+			remindAt = at(days, reminderWeekendHour)
+			if !remindAt.After(now) {
+				remindAt = at(days+7, reminderWeekendHour)
+			}
+		case ReminderPresetNextWeek:
+			days := (int(time.Monday) - int(local.Weekday()) + 7) % 7
+			if days == 0 {
+				days = 7
+			}
+			remindAt = at(days, reminderNextWeekHour)
+		default:
+			return time.Time{}, util.ErrInvalidReminderTime
+		}
+	}
+
+	if !remindAt.After(now) || remindAt.After(now.AddDate(0, 0, maxReminderDays)) {
+		return time.Time{}, util.ErrInvalidReminderTime
+	}
+
+	return remindAt.UTC(), nil
+}
@@ -0,0 +1,453 @@
+package link
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"joosum-backend/app/aiusage"
+	"joosum-backend/app/user"
+	localConfig "joosum-backend/pkg/config"
+	"joosum-backend/pkg/llm"
+	"joosum-backend/pkg/util"
+	"joosum-backend/pkg/vector"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// 시맨틱 검색 결과 기본/최대 개수
+	defaultSemanticSearchLimit = 20
+	maxSemanticSearchLimit     = 50
+	// 한 번의 임베딩 API 호출에 넣는 텍스트 수
+	embeddingBatchSize = 50
+	// 사용자당 한 번에 새로 임베딩하는 최대 링크 수. 나머지는 다음 임베딩 작업에서 이어서 임베딩합니다.
+	maxEmbeddingsPerSync = 200
+	// 임베딩 텍스트에 넣는 추출 본문의 최대 글자 수
+	maxEmbeddingContentLength = 2000
+	// 임베딩 작업에서 한 번에 가져오는 사용자 수
+	embeddingSyncUserBatchSize = 100
+)
+
+// 링크를 저장/수정할 때 본문 추출과 임베딩을 처리하는 작업자 수와 대기열 크기
+const (
+	linkIndexWorkers   = 4
+	linkIndexQueueSize = 1000
+)
+
+// 링크 색인 대기열. 첫 색인 요청 때 작업자를 시작합니다
+var linkIndexQueue struct {
+	once sync.Once
+	jobs chan func()
+}
+
+// Atlas Vector Search 를 쓰지 않을 때 사용자별 메모리 인덱스를 5분 동안 두고 사용합니다.
+// 이 인스턴스에서 임베딩을 저장하면 바로 지우고, 다른 인스턴스에서 저장한 임베딩은 만료 후 반영됩니다.
+const memoryIndexCacheTTL = 5 * time.Minute
+
+var memoryIndexCache = struct {
+	sync.Mutex
+	indexes  map[string]*vector.MemoryIndex
+	loadedAt map[string]time.Time
+}{
+	indexes:  map[string]*vector.MemoryIndex{},
+	loadedAt: map[string]time.Time{},
+}
+
+// EmbeddingSyncResult 는 전체 사용자 임베딩 작업의 결과입니다.
+type EmbeddingSyncResult struct {
+	Users   int // 처리한 사용자 수
+	Skipped int // AI 사용량 한도를 넘어 건너뛴 사용자 수
+	Failed  int
+}
+
+// SemanticSearch 검색어와 의미가 비슷한 사용자의 링크를 유사도 순으로 반환합니다
+// 링크 임베딩은 링크를 저장/수정할 때와 임베딩 작업(SyncAllLinkEmbeddings)에서 만들고, 검색에서는 검색어만 임베딩합니다
+func (u LinkUsecase) SemanticSearch(userId string, query string, limit int) ([]SemanticSearchLink, error) {
+	if limit <= 0 {
+		limit = defaultSemanticSearchLimit
+	} else if limit > maxSemanticSearchLimit {
+		limit = maxSemanticSearchLimit
+	}
+
+	provider, err := u.getEmbeddingProvider()
+	if err != nil {
+		log.Printf("[시맨틱 검색] 임베딩 제공자 생성 실패: %v", err)
+		return nil, err
+	}
+
+	resp, err := provider.Embed(context.Background(), []string{query})
+	if err != nil {
+		log.Printf("[시맨틱 검색] %s 검색어 임베딩 실패: %v", provider.Name(), err)
+		return nil, fmt.Errorf("failed to embed query: %v", err)
+	}
+	u.aiUsageUsecase.Record(aiusage.AIUsage{
+		UserId:       userId,
+		Feature:      aiusage.FeatureEmbedding,
+		Provider:     provider.Name(),
+		Model:        resp.Model,
+		PromptTokens: resp.PromptTokens,
+	})
+
+	matches, err := u.searchLinkEmbeddings(userId, provider.Model(), resp.Vectors[0], limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []SemanticSearchLink{}
+	if len(matches) == 0 {
+		return results, nil
+	}
+
+	linkIds := make([]string, 0, len(matches))
+	for _, m := range matches {
+		linkIds = append(linkIds, m.ID)
+	}
+
+	links, err := u.linkModel.GetLinksByLinkIds(userId, linkIds)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.resolveLinkBookNames(userId, links)
+	if err != nil {
+		return nil, err
+	}
+
+	linksById := make(map[string]*Link, len(links))
+	for _, link := range links {
+		linksById[link.LinkId] = link
+	}
+
+	// 유사도 순서 유지. 검색 사이에 삭제된 링크는 제외
+	for _, m := range matches {
+		link, ok := linksById[m.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, SemanticSearchLink{Link: *link, Score: m.Score})
+	}
+
+	return results, nil
+}
+
+// indexLinkInBackground 저장/수정한 링크의 본문 추출과 임베딩을 색인 대기열에 넣습니다. 요청은 기다리지 않습니다
+// extractContent 가 false 면 (URL 이 그대로면) 본문 추출은 건너뜁니다
+// 대기열이 가득 차 넣지 못했거나 임베딩에 실패한 링크는 다음 임베딩 작업에서 임베딩합니다
+func (u LinkUsecase) indexLinkInBackground(link *Link, extractContent bool) {
+	linkIndexQueue.once.Do(startLinkIndexWorkers)
+
+	job := func() {
+		u.indexLink(link.LinkId, extractContent)
+	}
+
+	select {
+	case linkIndexQueue.jobs <- job:
+	default:
+		log.Printf("[시맨틱 검색] 색인 대기열이 가득 차 건너뜀 (linkId=%s)", link.LinkId)
+	}
+}
+
+// startLinkIndexWorkers 색인 대기열을 처리하는 작업자를 linkIndexWorkers 개 시작합니다
+func startLinkIndexWorkers() {
+	linkIndexQueue.jobs = make(chan func(), linkIndexQueueSize)
+	for i := 0; i < linkIndexWorkers; i++ {
+		go func() {
+			for job := range linkIndexQueue.jobs {
+				job()
+			}
+		}()
+	}
+}
+
+// indexLink 링크의 본문을 추출해 저장하고 임베딩합니다
+// 대기열에 있는 사이 삭제된 링크는 건너뛰고, 처리하는 사이 삭제되면 다시 만든 본문과 임베딩을 지웁니다
+func (u LinkUsecase) indexLink(linkId string, extractContent bool) {
+	// 대기열에 넣은 뒤 URL 이 바뀌었을 수 있으므로 링크를 다시 조회
+	link, err := u.linkModel.GetOneLinkByLinkId(linkId)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			log.Printf("[시맨틱 검색] 링크 조회 실패 (linkId=%s): %v", linkId, err)
+		}
+		return
+	}
+	defer u.cleanUpDeletedLink(linkId)
+
+	if extractContent {
+		// 저장한 본문은 AI 요약, 링크 질문(ask), 임베딩에 사용
+		if _, err := u.getLinkContent(link); err != nil {
+			log.Printf("[AI 본문 추출] 본문 추출 실패 (linkId=%s): %v", link.LinkId, err)
+		}
+	}
+
+	err = u.checkEmbeddingQuota(link.UserID)
+	if err != nil {
+		return
+	}
+
+	provider, err := u.getEmbeddingProvider()
+	if err != nil {
+		log.Printf("[시맨틱 검색] 임베딩 제공자 생성 실패: %v", err)
+		return
+	}
+
+	err = u.syncLinkEmbeddings(link.UserID, provider, []*Link{link})
+	if err != nil {
+		log.Printf("[시맨틱 검색] %s 링크 임베딩 실패 (linkId=%s): %v", provider.Name(), link.LinkId, err)
+	}
+}
+
+// cleanUpDeletedLink 색인하는 사이 링크가 삭제됐으면 색인하며 저장한 본문과 임베딩을 지웁니다
+// 링크 삭제는 링크를 먼저 지우고 파생 데이터를 지우므로, 저장을 마친 뒤 링크가 없으면 삭제 요청이 놓친 데이터가 있을 수 있습니다
+func (u LinkUsecase) cleanUpDeletedLink(linkId string) {
+	_, err := u.linkModel.GetOneLinkByLinkId(linkId)
+	if err != mongo.ErrNoDocuments {
+		return
+	}
+
+	if err := u.linkModel.DeleteLinkDerivedData([]string{linkId}); err != nil {
+		log.Printf("[시맨틱 검색] 삭제된 링크의 본문/임베딩 삭제 실패 (linkId=%s): %v", linkId, err)
+	}
+}
+
+// SyncAllLinkEmbeddings 모든 사용자의 임베딩이 없거나 오래된 링크를 임베딩합니다 (스케줄러의 embedding 작업)
+// AI 사용량 한도를 넘은 사용자는 건너뛰고, 한 사용자의 실패는 다른 사용자 처리를 막지 않습니다
+func (u LinkUsecase) SyncAllLinkEmbeddings(ctx context.Context) (EmbeddingSyncResult, error) {
+	var result EmbeddingSyncResult
+
+	provider, err := u.getEmbeddingProvider()
+	if err != nil {
+		return result, err
+	}
+
+	after := ""
+	for {
+		userIds, err := (&user.UserModel{}).FindUserIds(after, embeddingSyncUserBatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(userIds) == 0 {
+			return result, nil
+		}
+
+		for _, userId := range userIds {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+
+			result.Users++
+			err := u.SyncLinkEmbeddings(userId, provider)
+			if err == util.ErrAIQuotaExceeded {
+				result.Skipped++
+				continue
+			}
+			if err != nil {
+				log.Printf("[시맨틱 검색] %s 링크 임베딩 실패 (userId=%s): %v", provider.Name(), userId, err)
+				result.Failed++
+			}
+		}
+		after = userIds[len(userIds)-1]
+	}
+}
+
+// SyncLinkEmbeddings 사용자의 임베딩이 없거나 오래된 링크를 최근 링크부터 최대 maxEmbeddingsPerSync 개 임베딩합니다
+// AI 사용량 한도를 넘었으면 util.ErrAIQuotaExceeded 를 반환합니다
+func (u LinkUsecase) SyncLinkEmbeddings(userId string, provider llm.EmbeddingProvider) error {
+	err := u.checkEmbeddingQuota(userId)
+	if err != nil {
+		return err
+	}
+
+	links, err := u.linkModel.GetAllLinkByUserId(userId, "created_at", "desc")
+	if err != nil {
+		return err
+	}
+
+	return u.syncLinkEmbeddings(userId, provider, links)
+}
+
+// checkEmbeddingQuota 사용량 한도를 넘은 사용자의 링크는 임베딩하지 않습니다
+func (u LinkUsecase) checkEmbeddingQuota(userId string) error {
+	err := u.aiUsageUsecase.CheckQuota(userId)
+	if err != nil && err != util.ErrAIQuotaExceeded {
+		log.Printf("[시맨틱 검색] 사용량 한도 확인 실패 (userId=%s): %v", userId, err)
+	}
+	return err
+}
+
+// syncLinkEmbeddings 링크 중 임베딩이 없거나 텍스트가 바뀐 링크를 임베딩해 저장합니다
+func (u LinkUsecase) syncLinkEmbeddings(userId string, provider llm.EmbeddingProvider, links []*Link) error {
+	if len(links) == 0 {
+		return nil
+	}
+
+	hashes, err := u.linkModel.GetLinkEmbeddingHashes(userId, provider.Model())
+	if err != nil {
+		return err
+	}
+
+	linkIds := make([]string, 0, len(links))
+	for _, link := range links {
+		linkIds = append(linkIds, link.LinkId)
+	}
+	contents, err := u.linkModel.GetLinkContentsByLinkIds(linkIds)
+	if err != nil {
+		return err
+	}
+	contentTexts := make(map[string]string, len(contents))
+	for _, content := range contents {
+		contentTexts[content.LinkId] = content.Text
+	}
+
+	var pending []*Link
+	var texts []string
+	for _, link := range links {
+		text := linkEmbeddingText(link, contentTexts[link.LinkId])
+		if hashes[link.LinkId] == hashText(text) {
+			continue
+		}
+
+		pending = append(pending, link)
+		texts = append(texts, text)
+	}
+
+	if len(pending) > maxEmbeddingsPerSync {
+		log.Printf("[시맨틱 검색] 임베딩할 링크 %d개 중 최근 %d개만 처리 (userId=%s)", len(pending), maxEmbeddingsPerSync, userId)
+		pending = pending[:maxEmbeddingsPerSync]
+		texts = texts[:maxEmbeddingsPerSync]
+	}
+
+	for start := 0; start < len(pending); start += embeddingBatchSize {
+		end := start + embeddingBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+
+		resp, err := provider.Embed(context.Background(), texts[start:end])
+		if err != nil {
+			return err
+		}
+		u.aiUsageUsecase.Record(aiusage.AIUsage{
+			UserId:       userId,
+			Feature:      aiusage.FeatureEmbedding,
+			Provider:     provider.Name(),
+			Model:        resp.Model,
+			PromptTokens: resp.PromptTokens,
+		})
+
+		for i, link := range pending[start:end] {
+			err := u.linkModel.UpsertLinkEmbedding(LinkEmbedding{
+				LinkId:    link.LinkId,
+				UserId:    userId,
+				Model:     provider.Model(),
+				Vector:    resp.Vectors[i],
+				TextHash:  hashText(texts[start+i]),
+				UpdatedAt: time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		invalidateMemoryIndex(userId, provider.Model())
+	}
+
+	return nil
+}
+
+// searchLinkEmbeddings 설정(vectorSearchIndex)이 있으면 Atlas Vector Search 로, 없으면 메모리 인덱스로 검색합니다
+// Atlas 검색에 실패해도 메모리 인덱스로 검색합니다
+func (u LinkUsecase) searchLinkEmbeddings(userId string, model string, query []float32, limit int) ([]vector.Match, error) {
+	if indexName := localConfig.GetEnvConfig("vectorSearchIndex"); indexName != "" {
+		matches, err := u.linkModel.VectorSearchLinkEmbeddings(indexName, userId, model, query, limit)
+		if err == nil {
+			return matches, nil
+		}
+		log.Printf("[시맨틱 검색] Atlas Vector Search 실패, 메모리 인덱스 사용 (index=%s): %v", indexName, err)
+	}
+
+	index, err := u.getMemoryIndex(userId, model)
+	if err != nil {
+		return nil, err
+	}
+
+	return index.Search(query, limit), nil
+}
+
+// getMemoryIndex 사용자의 저장된 벡터로 만든 메모리 인덱스를 반환합니다. 캐시가 만료되면 잠금 없이 다시 불러옵니다
+func (u LinkUsecase) getMemoryIndex(userId string, model string) (*vector.MemoryIndex, error) {
+	key := userId + ":" + model
+
+	memoryIndexCache.Lock()
+	loadedAt, ok := memoryIndexCache.loadedAt[key]
+	cached := memoryIndexCache.indexes[key]
+	memoryIndexCache.Unlock()
+
+	if ok && time.Since(loadedAt) < memoryIndexCacheTTL {
+		return cached, nil
+	}
+
+	embeddings, err := u.linkModel.GetLinkEmbeddingsByUserId(userId, model)
+	if err != nil {
+		return nil, err
+	}
+
+	index := vector.NewMemoryIndex()
+	for _, e := range embeddings {
+		index.Add(e.LinkId, e.Vector)
+	}
+
+	memoryIndexCache.Lock()
+	// 만료된 다른 사용자의 인덱스도 함께 정리
+	for k, t := range memoryIndexCache.loadedAt {
+		if time.Since(t) >= memoryIndexCacheTTL {
+			delete(memoryIndexCache.loadedAt, k)
+			delete(memoryIndexCache.indexes, k)
+		}
+	}
+	memoryIndexCache.indexes[key] = index
+	memoryIndexCache.loadedAt[key] = time.Now()
+	memoryIndexCache.Unlock()
+
+	return index, nil
+}
+
+// invalidateMemoryIndex 임베딩이 바뀐 사용자의 메모리 인덱스 캐시를 지웁니다
+func invalidateMemoryIndex(userId string, model string) {
+	key := userId + ":" + model
+
+	memoryIndexCache.Lock()
+	delete(memoryIndexCache.indexes, key)
+	delete(memoryIndexCache.loadedAt, key)
+	memoryIndexCache.Unlock()
+}
+
+// linkEmbeddingText 링크에서 임베딩할 텍스트를 만듭니다 (제목, 태그, AI 요약, URL, 추출 본문 앞부분)
+func linkEmbeddingText(link *Link, content string) string {
+	parts := []string{link.Title}
+	if len(link.Tags) > 0 {
+		parts = append(parts, strings.Join(link.Tags, ", "))
+	}
+	if link.Summary != nil {
+		parts = append(parts, link.Summary.Summary)
+		parts = append(parts, link.Summary.KeyPoints...)
+	}
+	parts = append(parts, link.URL)
+	if content = strings.TrimSpace(content); content != "" {
+		if runes := []rune(content); len(runes) > maxEmbeddingContentLength {
+			content = string(runes[:maxEmbeddingContentLength])
+		}
+		parts = append(parts, content)
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func hashText(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,181 @@
+package link
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"joosum-backend/app/aiusage"
+	"joosum-backend/pkg/llm"
+	"joosum-backend/pkg/util"
+)
+
+// 요약 응답에서 허용하는 핵심 포인트 최대 개수
+const maxSummaryKeyPoints = 5
+
+type summaryResponse struct {
+	Language  string   `json:"language"`
+	Summary   string   `json:"summary"`
+	KeyPoints []string `json:"keyPoints"`
+}
+
+var summaryResponseFormat = llm.ResponseFormat{
+	Name: "link_summary",
+	Schema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"language": {"type": "string", "enum": ["ko", "en"]},
+			"summary": {"type": "string"},
+			"keyPoints": {"type": "array", "items": {"type": "string"}}
+		},
+		"required": ["language", "summary", "keyPoints"],
+		"additionalProperties": false
+	}`),
+}
+
+// GenerateLinkSummary 링크 본문을 AI 로 요약해 링크에 저장합니다
+// 이미 요약이 있는 링크도 다시 호출하면 최신 본문으로 요약을 새로 만듭니다
+func (u LinkUsecase) GenerateLinkSummary(userId string, linkId string) (*Link, error) {
+	link, err := u.getOwnedLink(userId, linkId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 한도를 넘었으면 본문을 가져오기 전에 중단
+	provider, err := u.getMeteredLLMProvider(userId, aiusage.FeatureSummary, "")
+	if err != nil {
+		log.Printf("[AI 요약] LLM 제공자 생성 실패: %v", err)
+		return nil, err
+	}
+
+	content, err := u.getLinkContent(link)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := summarizeContent(provider, content, link.URL)
+	if err != nil {
+		log.Printf("[AI 요약] %s 요약 생성 실패 (linkId=%s): %v", provider.Name(), linkId, err)
+		return nil, fmt.Errorf("failed to generate AI summary: %v", err)
+	}
+
+	err = u.linkModel.UpdateSummaryByLinkId(linkId, summary)
+	if err != nil {
+		return nil, err
+	}
+	link.Summary = summary
+
+	linkBook, err := u.linkBookModel.GetLinkBookById(link.LinkBookId)
+	if err == nil {
+		link.LinkBookName = linkBook.Title
+	}
+
+	return link, nil
+}
+
+// getLinkContent 링크 본문을 새로 추출해 저장합니다
+// 페이지를 가져오지 못하면 이전에 저장해 둔 본문을 사용합니다
+func (u LinkUsecase) getLinkContent(link *Link) (string, error) {
+	url := util.EnsureHTTPPrefix(link.URL)
+
+	text, fetchErr := fetchPageContent(url)
+	if fetchErr == nil {
+		err := u.linkModel.UpsertLinkContent(LinkContent{
+			LinkId:      link.LinkId,
+			UserId:      link.UserID,
+			URL:         url,
+			Text:        text,
+			ExtractedAt: time.Now(),
+		})
+		if err != nil {
+			log.Printf("[AI 본문 추출] 본문 저장 실패 (linkId=%s): %v", link.LinkId, err)
+		}
+		return text, nil
+	}
+
+	stored, err := u.linkModel.GetLinkContent(link.LinkId)
+	if err != nil {
+		// 저장된 본문도 없으면 원래의 추출 에러를 반환
+		return "", fetchErr
+	}
+
+	log.Printf("[AI 본문 추출] 본문 추출 실패로 저장된 본문 사용 (linkId=%s): %v", link.LinkId, fetchErr)
+	return stored.Text, nil
+}
+
+// summarizeContent LLM 을 호출하여 본문 요약과 핵심 포인트를 만듭니다
+func summarizeContent(provider llm.LLMProvider, content string, url string) (*LinkSummary, error) {
+	systemPrompt := `당신은 웹 콘텐츠를 읽고 핵심을 짧게 정리하는 전문가입니다.
+
+다음 규칙을 엄격히 따라 요약하세요:
+
+- 본문이 한국어이면 한국어로, 그 외의 언어이면 영어로 작성
+- summary: 콘텐츠 전체를 2~3문장으로 요약
+- keyPoints: 핵심 내용을 3~5개의 짧은 문장으로 정리
+- 본문에 없는 내용은 추측하지 않음
+- 광고, 메뉴, 댓글 등 본문과 관계없는 내용은 제외
+- JSON 객체 형식으로만 응답: {"language": "ko 또는 en", "summary": "...", "keyPoints": ["...", "..."]}`
+
+	userPrompt := fmt.Sprintf("URL: %s\n\n콘텐츠:\n%s\n\n위 콘텐츠를 요약하여 JSON 객체로 반환하세요.", url, content)
+
+	req := llm.ChatRequest{
+		Messages: []llm.Message{
+			{
+				Role:    llm.RoleSystem,
+				Content: systemPrompt,
+			},
+			{
+				Role:    llm.RoleUser,
+				Content: userPrompt,
+			},
+		},
+		Temperature:    0.3,
+		MaxTokens:      600,
+		ResponseFormat: &summaryResponseFormat,
+	}
+
+	var parsed summaryResponse
+	resp, err := llm.ChatJSON(context.Background(), provider, req, &parsed)
+	if err != nil {
+		log.Printf("[AI 요약] LLM 호출 실패 (provider=%s, url=%s): %v", provider.Name(), url, err)
+		return nil, err
+	}
+
+	parsed.Summary = strings.TrimSpace(parsed.Summary)
+	if parsed.Summary == "" {
+		return nil, fmt.Errorf("empty summary in AI response: %s", resp.Content)
+	}
+
+	keyPoints := make([]string, 0, len(parsed.KeyPoints))
+	for _, point := range parsed.KeyPoints {
+		point = strings.TrimSpace(point)
+		if point == "" {
+			continue
+		}
+		keyPoints = append(keyPoints, point)
+		if len(keyPoints) == maxSummaryKeyPoints {
+			break
+		}
+	}
+
+	language := "en"
+	if strings.HasPrefix(strings.ToLower(parsed.Language), "ko") {
+		language = "ko"
+	}
+
+	model := resp.Model
+	if model == "" {
+		model = provider.Model()
+	}
+
+	return &LinkSummary{
+		Summary:     parsed.Summary,
+		KeyPoints:   keyPoints,
+		Language:    language,
+		Model:       model,
+		GeneratedAt: time.Now(),
+	}, nil
+}
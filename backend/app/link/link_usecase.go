@@ -4,16 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/PuerkitoBio/goquery"
-	"github.com/sashabaranov/go-openai"
+	"go.mongodb.org/mongo-driver/mongo"
 	"gopkg.in/errgo.v2/errors"
+	"joosum-backend/app/aiprompt"
+	"joosum-backend/app/aiusage"
+	"joosum-backend/app/tag"
 	localConfig "joosum-backend/pkg/config"
+	"joosum-backend/pkg/llm"
+	"joosum-backend/pkg/tagpolicy"
 	"joosum-backend/pkg/util"
 )
 
@@ -40,6 +48,56 @@ type YouTubeAPIResponse struct {
 type LinkUsecase struct {
 	linkModel     LinkModel
 	linkBookModel LinkBookModel
+	tagUsecase    tag.TagUsecase
+	// 비어 있으면 설정(llmProvider)에 따라 생성합니다. 테스트에서는 llm.FakeProvider 를 넣어 사용합니다.
+	llmProvider llm.LLMProvider
+	// 비어 있으면 설정(embeddingProvider)에 따라 생성합니다. 테스트에서는 llm.FakeEmbeddingProvider 를 넣어 사용합니다.
+	embeddingProvider llm.EmbeddingProvider
+	aiUsageUsecase    aiusage.AIUsageUsecase
+	aiPromptUsecase   aiprompt.AIPromptUsecase
+}
+
+// getLLMProvider 는 AI 기능에 사용할 LLM 제공자를 반환합니다.
+func (u LinkUsecase) getLLMProvider() (llm.LLMProvider, error) {
+	if u.llmProvider != nil {
+		return u.llmProvider, nil
+	}
+	return llm.NewProvider()
+}
+
+// getMeteredLLMProvider 는 사용자의 AI 사용량 한도를 확인한 뒤, 호출마다 토큰 사용량을 기록하는 LLM 제공자를 반환합니다.
+// 버전이 있는 프롬프트를 사용하면 promptVersion 을 함께 기록합니다.
+// 한도를 넘었으면 util.ErrAIQuotaExceeded 를 반환합니다.
+func (u LinkUsecase) getMeteredLLMProvider(userId string, feature string, promptVersion string) (llm.LLMProvider, error) {
+	err := u.aiUsageUsecase.CheckQuota(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := u.getLLMProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	return llm.WithUsageRecorder(provider, func(resp *llm.ChatResponse) {
+		u.aiUsageUsecase.Record(aiusage.AIUsage{
+			UserId:           userId,
+			Feature:          feature,
+			Provider:         provider.Name(),
+			Model:            resp.Model,
+			PromptTokens:     resp.PromptTokens,
+			CompletionTokens: resp.CompletionTokens,
+			PromptVersion:    promptVersion,
+		})
+	}), nil
+}
+
+// getEmbeddingProvider 는 시맨틱 검색에 사용할 임베딩 제공자를 반환합니다.
+func (u LinkUsecase) getEmbeddingProvider() (llm.EmbeddingProvider, error) {
+	if u.embeddingProvider != nil {
+		return u.embeddingProvider, nil
+	}
+	return llm.NewEmbeddingProvider()
 }
 
 func (u LinkUsecase) CreateLink(url string, title string, userId string, linkBookId string, thumbnailURL string, tags []string) (*Link, error) {
@@ -77,15 +135,44 @@ func (u LinkUsecase) CreateLink(url string, title string, userId string, linkBoo
 		return nil, err
 	}
 
+	// 본문 추출과 시맨틱 검색용 임베딩은 응답을 기다리지 않고 처리
+	u.indexLinkInBackground(link, true)
+
 	return link, nil
 }
 
+// resolveLinkBookNames 는 링크에 복사된 링크북 이름 대신 현재 링크북 제목으로 채웁니다.
+// 링크북 이름 변경이 링크에 전파되지 못한 경우에도 최신 이름을 보여주기 위함입니다.
+func (u LinkUsecase) resolveLinkBookNames(userId string, links []*Link) error {
+	if len(links) == 0 {
+		return nil
+	}
+
+	titles, err := u.linkBookModel.GetLinkBookTitles(userId)
+	if err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		if title, ok := titles[link.LinkBookId]; ok {
+			link.LinkBookName = title
+		}
+	}
+
+	return nil
+}
+
 func (u LinkUsecase) Get9LinksByUserId(userId string) ([]*Link, error) {
 	links, err := u.linkModel.Get9LinksByUserId(userId)
 	if err != nil {
 		return nil, err
 	}
 
+	err = u.resolveLinkBookNames(userId, links)
+	if err != nil {
+		return nil, err
+	}
+
 	return links, nil
 }
 
@@ -95,6 +182,15 @@ func (u LinkUsecase) FindOneLinkByLinkId(linkId string) (*Link, error) {
 		return nil, err
 	}
 
+	// 링크북이 삭제되어 없으면 저장된 이름을 그대로 씀
+	linkBook, err := u.linkBookModel.GetLinkBookById(link.LinkBookId)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+	if linkBook != nil {
+		link.LinkBookName = linkBook.Title
+	}
+
 	return link, nil
 }
 
@@ -104,6 +200,11 @@ func (u LinkUsecase) FindAllLinksByUserId(userId string, sort string, order stri
 		return nil, err
 	}
 
+	err = u.resolveLinkBookNames(userId, links)
+	if err != nil {
+		return nil, err
+	}
+
 	// if links length 0 return []
 	if len(links) == 0 {
 		return []*Link{}, nil
@@ -118,6 +219,11 @@ func (u LinkUsecase) FindAllLinksByUserIdAndSearch(userId string, search string,
 		return nil, err
 	}
 
+	err = u.resolveLinkBookNames(userId, links)
+	if err != nil {
+		return nil, err
+	}
+
 	// if links length 0 return []
 	if len(links) == 0 {
 		return []*Link{}, nil
@@ -132,6 +238,11 @@ func (u LinkUsecase) FindAllLinksByUserIdAndLinkBookId(userId string, linkBookId
 		return nil, err
 	}
 
+	err = u.resolveLinkBookNames(userId, links)
+	if err != nil {
+		return nil, err
+	}
+
 	return links, nil
 }
 
@@ -141,6 +252,11 @@ func (u LinkUsecase) FindAllLinksByUserIdAndLinkBookIdAndSearch(userId string, l
 		return nil, err
 	}
 
+	err = u.resolveLinkBookNames(userId, links)
+	if err != nil {
+		return nil, err
+	}
+
 	// if links length 0 return []
 	if len(links) == 0 {
 		return []*Link{}, nil
@@ -155,6 +271,11 @@ func (u LinkUsecase) DeleteOneByLinkId(linkId string) error {
 		return err
 	}
 
+	// 본문과 임베딩은 AI 기능용 부가 데이터라 정리에 실패해도 삭제는 성공으로 처리
+	if err := u.linkModel.DeleteLinkDerivedData([]string{linkId}); err != nil {
+		log.Printf("[링크 삭제] 본문/임베딩 삭제 실패 (linkId=%s): %v", linkId, err)
+	}
+
 	return nil
 }
 
@@ -165,6 +286,10 @@ func (u LinkUsecase) DeleteAllLinks(userId string, linkIds []string) (int64, err
 			return 0, err
 		}
 
+		if err := u.linkModel.DeleteLinkDerivedDataByUserId(userId); err != nil {
+			log.Printf("[링크 삭제] 본문/임베딩 삭제 실패 (userId=%s): %v", userId, err)
+		}
+
 		return deletedCount, nil
 
 	} else if strings.HasPrefix(linkIds[0], "Link-") {
@@ -173,6 +298,10 @@ func (u LinkUsecase) DeleteAllLinks(userId string, linkIds []string) (int64, err
 			return 0, err
 		}
 
+		if err := u.linkModel.DeleteLinkDerivedData(linkIds); err != nil {
+			log.Printf("[링크 삭제] 본문/임베딩 삭제 실패 (userId=%s): %v", userId, err)
+		}
+
 		return deletedCount, nil
 	} else {
 		return 0, errors.New("Invalid query parameter")
@@ -180,11 +309,20 @@ func (u LinkUsecase) DeleteAllLinks(userId string, linkIds []string) (int64, err
 }
 
 func (u LinkUsecase) DeleteAllLinksByLinkBookId(userId string, linkBookId string) error {
-	_, err := u.linkModel.DeleteAllLinksByLinkBookId(userId, linkBookId)
+	linkIds, err := u.linkModel.GetLinkIdsByLinkBookId(userId, linkBookId)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.linkModel.DeleteAllLinksByLinkBookId(userId, linkBookId)
 	if err != nil {
 		return err
 	}
 
+	if err := u.linkModel.DeleteLinkDerivedData(linkIds); err != nil {
+		log.Printf("[링크 삭제] 본문/임베딩 삭제 실패 (linkBookId=%s): %v", linkBookId, err)
+	}
+
 	return nil
 }
 
@@ -194,6 +332,9 @@ func (u LinkUsecase) UpdateReadByLinkId(linkId string) error {
 		return err
 	}
 
+	// 읽은 링크는 더 이상 "나중에 읽기" 알림을 보내지 않음
+	u.cancelRemindersOnRead(linkId)
+
 	return nil
 
 }
@@ -218,11 +359,22 @@ func (u LinkUsecase) UpdateLinkBookIdByLinkId(linkId string, linkBookId string)
 func (u LinkUsecase) UpdateTitleAndUrlByLinkId(linkId string, url string, title string, thumbnailURL string, tags []string) (*Link, error) {
 	// URL 이 http:// 혹은 https:// 로 시작하지 않으면 https:// 를 붙입니다.
 	url = util.EnsureHTTPPrefix(url)
+
+	previous, err := u.linkModel.GetOneLinkByLinkId(linkId)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
 	link, err := u.linkModel.UpdateTitleAndUrlByLinkId(linkId, url, title, thumbnailURL, tags)
 	if err != nil {
 		return nil, err
 	}
 
+	// URL 이 바뀌었으면 본문을 다시 추출하고, 제목/태그가 바뀌었으면 임베딩을 다시 만듦
+	if link != nil {
+		u.indexLinkInBackground(link, previous == nil || previous.URL != link.URL)
+	}
+
 	return link, nil
 }
 
@@ -431,21 +583,133 @@ func getYouTubeVideoInfo(videoID string) (*YouTubeVideoInfo, error) {
 }
 
 // GetAIRecommendedTags AI를 사용하여 URL의 본문 내용을 분석하고 추천 태그를 생성합니다
-func (LinkUsecase) GetAIRecommendedTags(url string) (*AITagRecommendationRes, error) {
+// 사용자가 이미 가진 태그와 같은 의미의 태그는 기존 태그로 맞춰 추천합니다
+func (u LinkUsecase) GetAIRecommendedTags(userId string, url string) (*AITagRecommendationRes, error) {
 	// URL 이 http:// 혹은 https:// 로 시작하지 않으면 https:// 를 붙입니다.
 	url = util.EnsureHTTPPrefix(url)
 
-	// YouTube URL 감지
-	isYouTube := strings.Contains(url, "youtube.com") || strings.Contains(url, "youtu.be")
+	// 사용자의 기존 태그 (최근 사용 순)
+	userTags, err := u.tagUsecase.FindTagsByUserId(userId)
+	if err != nil && err != mongo.ErrNoDocuments {
+		log.Printf("[AI 태그 추천] 사용자 태그 조회 실패 (userId=%s): %v", userId, err)
+		return nil, err
+	}
+
+	provider, err := u.getLLMProvider()
+	if err != nil {
+		log.Printf("[AI 태그 추천] LLM 제공자 생성 실패: %v", err)
+		return nil, err
+	}
 
-	// User-Agent와 헤더를 설정한 HTTP 클라이언트 생성
-	client := &http.Client{
-		Timeout: 15 * time.Second,
+	// 설정된 버전(A/B 비율이 있으면 사용자별 버전)의 프롬프트
+	prompt, err := u.aiPromptUsecase.SelectPrompt(aiprompt.NameAITags, userId)
+	if err != nil {
+		log.Printf("[AI 태그 추천] 프롬프트 로드 실패: %v", err)
+		return nil, err
+	}
+
+	// 같은 페이지에 대한 재요청은 (다른 사용자의 요청이어도) 캐시된 결과를 사용 (한도에 포함되지 않음)
+	// 캐시는 사용자와 무관한 LLM 결과이고, 사용자의 기존 태그로 맞추는 것은 캐시 다음에 함
+	cacheKey := aiTagCacheKey(util.CanonicalURL(url), prompt, provider.Model())
+	tags, err := u.recommendTagsWithCache(userId, cacheKey, prompt, url)
+	if err != nil {
+		return nil, err
+	}
+
+	// 사용자의 기존 태그로 맞추는 LLM 호출은 한도에 포함. 한도를 넘었으면 표기만 다른 태그만 기존 태그로 맞춤
+	var matchProvider llm.LLMProvider
+	if len(userTags) > 0 {
+		matchProvider, err = u.getMeteredLLMProvider(userId, aiusage.FeatureTags, prompt.Version)
+		if err != nil {
+			log.Printf("[AI 태그 추천] 기존 태그 맞춤 건너뜀 (userId=%s): %v", userId, err)
+			matchProvider = nil
+		}
 	}
+
+	personalized := personalizeRecommendedTags(matchProvider, tags, userTags)
+	recommendedTags := make([]string, 0, len(personalized))
+	for _, t := range personalized {
+		recommendedTags = append(recommendedTags, t.Name)
+	}
+
+	return &AITagRecommendationRes{
+		URL:             url,
+		RecommendedTags: recommendedTags,
+		Tags:            personalized,
+		PromptVersion:   prompt.Version,
+	}, nil
+}
+
+// recommendTagsWithCache 캐시에 결과가 있으면 반환하고, 없으면 한도를 확인한 뒤 LLM 으로 태그를 추천받아 캐시에 저장합니다
+// 캐시에는 정책 필터 전의 LLM 결과를 저장하고, 반환할 때 정책 필터를 적용합니다
+func (u LinkUsecase) recommendTagsWithCache(userId string, cacheKey string, prompt *aiprompt.AIPrompt, url string) ([]string, error) {
+	cached, err := u.linkModel.GetAITagCache(cacheKey)
+	if err == nil {
+		u.aiUsageUsecase.Record(aiusage.AIUsage{
+			UserId:        userId,
+			Feature:       aiusage.FeatureTags,
+			Provider:      "cache",
+			Model:         cached.Model,
+			PromptVersion: cached.PromptVersion,
+			Cached:        true,
+		})
+		// 캐시에는 정책 필터 전의 결과가 있으므로 반환할 때 적용
+		tags, _ := filterRecommendedTags(cached.Tags, url)
+		return tags, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		log.Printf("[AI 태그 추천] 캐시 조회 실패 (url=%s): %v", url, err)
+	}
+
+	provider, err := u.getMeteredLLMProvider(userId, aiusage.FeatureTags, prompt.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := fetchPageContent(url)
+	if err != nil {
+		return nil, err
+	}
+
+	// LLM 호출하여 태그 추천 받기. 캐시를 사용자끼리 나눠 쓰도록 사용자 태그는 프롬프트에 넣지 않음
+	rawTags, err := recommendTags(provider, prompt, content, url)
+	if err != nil {
+		log.Printf("[AI 태그 추천] %s 태그 생성 실패 (url=%s): %v", provider.Name(), url, err)
+		return nil, fmt.Errorf("failed to get AI recommendations: %v", err)
+	}
+
+	err = u.linkModel.UpsertAITagCache(AITagCache{
+		Key:           cacheKey,
+		URL:           util.CanonicalURL(url),
+		PromptVersion: prompt.Version,
+		Model:         provider.Model(),
+		Tags:          rawTags,
+		CreatedAt:     time.Now(),
+	})
+	if err != nil {
+		log.Printf("[AI 태그 추천] 캐시 저장 실패 (url=%s): %v", url, err)
+	}
+
+	tags, _ := filterRecommendedTags(rawTags, url)
+	return tags, nil
+}
+
+// aiTagCacheKey 태그 추천 캐시 키를 만듭니다 (정규화한 URL, 프롬프트 버전, 모델)
+// 같은 버전의 템플릿을 고쳐도 이전 캐시를 쓰지 않도록 템플릿 해시를 함께 넣습니다
+func aiTagCacheKey(canonicalURL string, prompt *aiprompt.AIPrompt, model string) string {
+	parts := []string{canonicalURL, prompt.Version, prompt.Hash(), model}
+	return hashText(strings.Join(parts, "\x00"))
+}
+
+// 사용자가 저장한 페이지를 가져오는 클라이언트. 서버 내부망, 루프백 주소로는 연결하지 않습니다
+var pageClient = util.NewPublicHTTPClient(15 * time.Second)
+
+// fetchPageContent URL 의 HTML 을 가져와 AI 분석용 본문을 만듭니다
+func fetchPageContent(url string) (string, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		log.Printf("[AI 태그 추천] HTTP 요청 생성 실패 (url=%s): %v", url, err)
-		return nil, fmt.Errorf("failed to create request: %v", err)
+		log.Printf("[AI 본문 추출] HTTP 요청 생성 실패 (url=%s): %v", url, err)
+		return "", fmt.Errorf("failed to create request: %v", err)
 	}
 
 	// 브라우저처럼 보이도록 헤더 설정 (봇 차단 방지)
@@ -455,24 +719,56 @@ func (LinkUsecase) GetAIRecommendedTags(url string) (*AITagRecommendationRes, er
 	// Accept-Encoding은 설정하지 않음 - Go의 http.Client가 자동으로 gzip 처리
 
 	// URL에서 본문 내용 크롤링
-	resp, err := client.Do(req)
+	resp, err := pageClient.Do(req)
 	if err != nil {
-		log.Printf("[AI 태그 추천] URL 크롤링 실패 (url=%s): %v", url, err)
-		return nil, fmt.Errorf("failed to fetch URL: %v", err)
+		log.Printf("[AI 본문 추출] URL 크롤링 실패 (url=%s): %v", url, err)
+		return "", fmt.Errorf("failed to fetch URL: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		log.Printf("[AI 태그 추천] URL 응답 코드 비정상 (url=%s, status=%s)", url, resp.Status)
-		return nil, fmt.Errorf("status code error: %d %s", resp.StatusCode, resp.Status)
+		log.Printf("[AI 본문 추출] URL 응답 코드 비정상 (url=%s, status=%s)", url, resp.Status)
+		return "", fmt.Errorf("status code error: %d %s", resp.StatusCode, resp.Status)
 	}
 
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
-		log.Printf("[AI 태그 추천] HTML 파싱 실패 (url=%s): %v", url, err)
-		return nil, fmt.Errorf("failed to parse HTML: %v", err)
+		log.Printf("[AI 본문 추출] HTML 파싱 실패 (url=%s): %v", url, err)
+		return "", fmt.Errorf("failed to parse HTML: %v", err)
+	}
+
+	return buildPageContent(doc, url)
+}
+
+// RecommendTagsFromHTML 이미 받아 둔 HTML 로 태그 추천 파이프라인(본문 추출 → LLM → 정책 필터 → 기존 태그 맞춤)을 실행합니다
+// 기존 태그 맞춤도 서비스와 같이 provider 로 LLM 을 호출합니다
+// 정책 필터에서 제외된 태그도 함께 반환합니다. 오프라인 평가 도구(cmd/tageval)에서 사용합니다
+func RecommendTagsFromHTML(provider llm.LLMProvider, prompt *aiprompt.AIPrompt, html io.Reader, url string, userTags []string) ([]AIRecommendedTag, []tagpolicy.Rejected, error) {
+	doc, err := goquery.NewDocumentFromReader(html)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse HTML: %v", err)
+	}
+
+	content, err := buildPageContent(doc, url)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tags, err := recommendTags(provider, prompt, content, url)
+	if err != nil {
+		return nil, nil, err
 	}
 
+	tags, rejected := filterRecommendedTags(tags, url)
+
+	return personalizeRecommendedTags(provider, tags, userTags), rejected, nil
+}
+
+// buildPageContent HTML 문서에서 제목, 설명, 키워드, 본문, 해시태그를 추출해 AI 분석용 본문을 만듭니다
+func buildPageContent(doc *goquery.Document, url string) (string, error) {
+	// YouTube URL 감지
+	isYouTube := strings.Contains(url, "youtube.com") || strings.Contains(url, "youtu.be")
+
 	// 본문 텍스트 추출
 	var contentBuilder strings.Builder
 
@@ -603,7 +899,7 @@ func (LinkUsecase) GetAIRecommendedTags(url string) (*AITagRecommendationRes, er
 			}
 		}
 
-		log.Printf("[AI 태그 추천] YouTube 특별 처리 완료 (url=%s, 현재 길이=%d)", url, contentBuilder.Len())
+		log.Printf("[AI 본문 추출] YouTube 특별 처리 완료 (url=%s, 현재 길이=%d)", url, contentBuilder.Len())
 	}
 
 	// 5. Naver 특별 처리: 동적 페이지 대응
@@ -620,7 +916,7 @@ func (LinkUsecase) GetAIRecommendedTags(url string) (*AITagRecommendationRes, er
 			contentBuilder.WriteString("공고 ID가 포함된 URL입니다.\n")
 		}
 
-		log.Printf("[AI 태그 추천] Naver 특별 처리 완료 (url=%s, 현재 길이=%d)", url, contentBuilder.Len())
+		log.Printf("[AI 본문 추출] Naver 특별 처리 완료 (url=%s, 현재 길이=%d)", url, contentBuilder.Len())
 	}
 
 	// 6. 본문 내용 추출 (article, main, section, div 태그)
@@ -690,12 +986,12 @@ func (LinkUsecase) GetAIRecommendedTags(url string) (*AITagRecommendationRes, er
 	// 본문이 너무 짧은 경우 체크
 	if len(content) < 100 {
 		if hasMinimalInfo {
-			log.Printf("[AI 태그 추천] 본문이 부족하지만 메타 태그/컨텍스트로 진행 (url=%s, content_length=%d, meta_length=%d, title_length=%d, desc_length=%d, hasContext=%v)",
+			log.Printf("[AI 본문 추출] 본문이 부족하지만 메타 태그/컨텍스트로 진행 (url=%s, content_length=%d, meta_length=%d, title_length=%d, desc_length=%d, hasContext=%v)",
 				url, len(content), metaContentLength, len(title), len(description), hasContext)
 			// 메타 태그 또는 컨텍스트 내용으로 진행 - AI가 판단하도록 함
 		} else {
-			log.Printf("[AI 태그 추천] 본문 추출 실패: 정보 없음 (url=%s, length=%d, meta_length=%d)", url, len(content), metaContentLength)
-			return nil, fmt.Errorf("insufficient content extracted from URL")
+			log.Printf("[AI 본문 추출] 본문 추출 실패: 정보 없음 (url=%s, length=%d, meta_length=%d)", url, len(content), metaContentLength)
+			return "", fmt.Errorf("insufficient content extracted from URL")
 		}
 	}
 
@@ -704,115 +1000,270 @@ func (LinkUsecase) GetAIRecommendedTags(url string) (*AITagRecommendationRes, er
 		content = content[:8000] + "..."
 	}
 
-	// OpenAI API 호출하여 태그 추천 받기
-	tags, err := callOpenAIForTags(content, url)
-	if err != nil {
-		log.Printf("[AI 태그 추천] OpenAI 태그 생성 실패 (url=%s): %v", url, err)
-		return nil, fmt.Errorf("failed to get AI recommendations: %v", err)
+	return content, nil
+}
+
+// 기존 태그 맞춤 프롬프트에 넣을 기존 태그 최대 개수
+const maxUserTagsInPrompt = 100
+
+// 태그 추천 응답 스키마. structured output 은 최상위가 객체여야 하므로 배열을 tags 로 감쌉니다.
+var aiTagResponseFormat = llm.ResponseFormat{
+	Name: "ai_tags",
+	Schema: json.RawMessage(`{
+		"type": "object",
+		"properties": {"tags": {"type": "array", "items": {"type": "string"}}},
+		"required": ["tags"],
+		"additionalProperties": false
+	}`),
+}
+
+// 기존 태그 맞춤 응답 스키마
+var tagMatchResponseFormat = llm.ResponseFormat{
+	Name: "tag_matches",
+	Schema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"matches": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {
+						"tag": {"type": "string"},
+						"userTag": {"type": "string"}
+					},
+					"required": ["tag", "userTag"],
+					"additionalProperties": false
+				}
+			}
+		},
+		"required": ["matches"],
+		"additionalProperties": false
+	}`),
+}
+
+type tagMatchResponse struct {
+	Tag     string `json:"tag"`
+	UserTag string `json:"userTag"`
+}
+
+// aiTagPromptData 태그 추천 프롬프트 템플릿에 넣는 데이터
+// 추천 결과를 사용자끼리 캐시로 나눠 쓰므로 사용자별 정보는 넣지 않습니다
+type aiTagPromptData struct {
+	URL     string
+	Content string
+}
+
+// normalizeTagKey 는 대소문자, 공백, 구분자 차이를 무시하고 태그를 비교하기 위한 키를 만듭니다.
+func normalizeTagKey(tag string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(tag) {
+		if unicode.IsSpace(r) || r == '-' || r == '_' || r == '.' || r == '#' {
+			continue
+		}
+		b.WriteRune(r)
 	}
+	return b.String()
+}
 
-	return &AITagRecommendationRes{
-		URL:             url,
-		RecommendedTags: tags,
-	}, nil
+// personalizeTags 는 추천 태그를 사용자의 기존 태그로 매핑하고, 기존 태그를 먼저 오도록 정렬합니다.
+// 기존 태그끼리는 사용자가 최근에 사용한 순서, 새 태그끼리는 AI 추천 순서를 유지합니다.
+func personalizeTags(recommended []string, userTags []string) []AIRecommendedTag {
+	existing := make(map[string]int, len(userTags))
+	for i, t := range userTags {
+		key := normalizeTagKey(t)
+		if _, ok := existing[key]; !ok {
+			existing[key] = i
+		}
+	}
+
+	var matched []int
+	var newTags []AIRecommendedTag
+	seen := map[string]bool{}
+	for _, t := range recommended {
+		key := normalizeTagKey(t)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if idx, ok := existing[key]; ok {
+			matched = append(matched, idx)
+			continue
+		}
+		newTags = append(newTags, AIRecommendedTag{Name: strings.TrimSpace(t), IsExisting: false})
+	}
+
+	sort.Ints(matched)
+	result := make([]AIRecommendedTag, 0, len(matched)+len(newTags))
+	for _, idx := range matched {
+		result = append(result, AIRecommendedTag{Name: userTags[idx], IsExisting: true})
+	}
+
+	return append(result, newTags...)
 }
 
-// callOpenAIForTags OpenAI API를 호출하여 태그 추천을 받습니다
-func callOpenAIForTags(content string, url string) ([]string, error) {
-	apiKey := localConfig.GetEnvConfig("openaiApiKey")
-	if apiKey == "" {
-		log.Printf("[AI 태그 추천] OpenAI API 키가 설정되지 않았습니다.")
-		return nil, fmt.Errorf("OpenAI API key not configured")
+// personalizeRecommendedTags 는 추천 태그를 사용자의 기존 태그로 맞추고, 기존 태그를 먼저 오도록 정렬합니다.
+// 표기만 다른 태그는 personalizeTags 로 맞추고, 남은 새 태그는 같은 의미의 기존 태그가 있는지 LLM 에 묻습니다. (예: 리액트 → React)
+// provider 가 nil 이거나 LLM 호출에 실패하면 표기만 비교한 결과를 반환합니다.
+func personalizeRecommendedTags(provider llm.LLMProvider, recommended []string, userTags []string) []AIRecommendedTag {
+	personalized := personalizeTags(recommended, userTags)
+	if provider == nil || len(userTags) == 0 {
+		return personalized
 	}
 
-	client := openai.NewClient(apiKey)
+	var newTags []string
+	for _, t := range personalized {
+		if !t.IsExisting {
+			newTags = append(newTags, t.Name)
+		}
+	}
+	if len(newTags) == 0 {
+		return personalized
+	}
 
-	// AI 태그 정책에 따른 시스템 프롬프트
-	systemPrompt := `당신은 웹 콘텐츠를 분석하여 검색에 유용한 태그를 추천하는 전문가입니다.
+	promptTags := userTags
+	if len(promptTags) > maxUserTagsInPrompt {
+		promptTags = promptTags[:maxUserTagsInPrompt]
+	}
 
-다음 규칙을 엄격히 따라 태그를 생성하세요:
+	matches, err := matchUserTags(provider, newTags, promptTags)
+	if err != nil {
+		log.Printf("[AI 태그 추천] %s 기존 태그 맞춤 실패: %v", provider.Name(), err)
+		return personalized
+	}
+	if len(matches) == 0 {
+		return personalized
+	}
 
-**포함해야 할 태그:**
-- 핵심 개념/명사형 주제어 (예: 프롬프트, UX리서치, 포트폴리오)
-- 고유명사 (예: ChatGPT, Notion, 토스, Apple, Figma)
-- 전문 용어 (예: SBI모델, 파인튜닝, 데이터레이블링)
-- 도메인 단어 (예: 클라우드, 마케팅, 디자인) - 문맥상 의미 있을 때만
-- 본문 내 해시태그
+	mapped := make([]string, 0, len(recommended))
+	for _, t := range recommended {
+		if userTag, ok := matches[normalizeTagKey(t)]; ok {
+			t = userTag
+		}
+		mapped = append(mapped, t)
+	}
 
-**절대 제외해야 할 태그:**
-- 도메인명/플랫폼명 (예: yozm, naver, brunch, medium)
-- 감정 형용사 (예: 멋진, 새로운, 유용한)
-- 문장형 표현 (예: ~하는 방법, ~를 해보자)
-- CTA 문구 (예: 클릭, 확인, 공유, 신청하기)
-- 날짜/버전 정보 (예: 2024, 1.0, 7월)
-- 이모지/특수문자
+	return personalizeTags(mapped, userTags)
+}
 
-**형식 규칙:**
-- 명사형 중심으로 작성
-- 1~10자 사이의 짧고 직관적인 단어
-- 최대 5개까지만 추천
-- JSON 배열 형식으로만 응답: ["태그1", "태그2", ...]
+// matchUserTags LLM 에 추천 태그 중 기존 태그와 같은 의미인 태그를 물어 추천 태그(normalizeTagKey) → 기존 태그 매핑을 반환합니다
+// 추천하지 않은 태그나 기존 태그 목록에 없는 태그로 답한 항목은 버립니다
+func matchUserTags(provider llm.LLMProvider, tags []string, userTags []string) (map[string]string, error) {
+	systemPrompt := `당신은 태그의 의미를 비교하는 전문가입니다.
 
-콘텐츠의 핵심 주제를 파악하고, 사용자가 나중에 검색할 때 유용한 태그만 선정하세요.`
+다음 규칙을 엄격히 따라 추천 태그와 기존 태그를 짝지으세요:
 
-	userPrompt := fmt.Sprintf("URL: %s\n\n콘텐츠:\n%s\n\n위 콘텐츠를 분석하여 최대 5개의 추천 태그를 JSON 배열로 반환하세요.", url, content)
+- 추천 태그와 기존 태그가 같은 대상을 가리킬 때만 짝지음 (예: 리액트=React, 인공지능=AI, 자바스크립트=JavaScript)
+- 상위/하위 개념이거나 관련만 있는 태그는 짝짓지 않음 (예: React 와 프론트엔드)
+- userTag 는 기존 태그 목록의 철자 그대로 사용
+- 같은 의미의 기존 태그가 없는 추천 태그는 matches 에 넣지 않음
+- JSON 객체 형식으로만 응답: {"matches": [{"tag": "...", "userTag": "..."}]}`
 
-	ctx := context.Background()
-	req := openai.ChatCompletionRequest{
-		Model: "gpt-4o-mini", // GPT-4o mini 모델 사용
-		Messages: []openai.ChatCompletionMessage{
+	userPrompt := fmt.Sprintf("추천 태그: %s\n기존 태그: %s\n\n추천 태그와 같은 의미의 기존 태그를 {\"matches\": [...]} 형식의 JSON 으로 반환하세요.", strings.Join(tags, ", "), strings.Join(userTags, ", "))
+
+	req := llm.ChatRequest{
+		Messages: []llm.Message{
 			{
-				Role:    openai.ChatMessageRoleSystem,
+				Role:    llm.RoleSystem,
 				Content: systemPrompt,
 			},
 			{
-				Role:    openai.ChatMessageRoleUser,
+				Role:    llm.RoleUser,
 				Content: userPrompt,
 			},
 		},
-		Temperature:           0.3, // 일관성을 위해 낮은 temperature 사용
-		MaxCompletionTokens:   200,
+		Temperature:    0,
+		MaxTokens:      300,
+		ResponseFormat: &tagMatchResponseFormat,
 	}
 
-	resp, err := client.CreateChatCompletion(ctx, req)
+	var parsed struct {
+		Matches []tagMatchResponse `json:"matches"`
+	}
+	_, err := llm.ChatJSON(context.Background(), provider, req, &parsed)
 	if err != nil {
-		log.Printf("[AI 태그 추천] OpenAI API 호출 실패 (url=%s): %v", url, err)
-		return nil, fmt.Errorf("OpenAI API error: %v", err)
+		return nil, err
 	}
 
-	if len(resp.Choices) == 0 {
-		log.Printf("[AI 태그 추천] OpenAI 응답이 비어 있습니다 (url=%s)", url)
-		return nil, fmt.Errorf("no response from OpenAI")
+	recommended := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		recommended[normalizeTagKey(t)] = true
+	}
+	existing := make(map[string]bool, len(userTags))
+	for _, t := range userTags {
+		existing[t] = true
 	}
 
-	responseText := strings.TrimSpace(resp.Choices[0].Message.Content)
-
-	// 마크다운 코드 블록 제거 (```json ... ``` 형식)
-	if strings.HasPrefix(responseText, "```") {
-		// 첫 번째 줄바꿈 이후부터 마지막 ``` 이전까지 추출
-		lines := strings.Split(responseText, "\n")
-		if len(lines) > 2 {
-			// 첫 줄(```json)과 마지막 줄(```) 제거
-			responseText = strings.Join(lines[1:len(lines)-1], "\n")
-			responseText = strings.TrimSpace(responseText)
-			log.Printf("[AI 태그 추천] 마크다운 코드 블록 제거 완료 (url=%s)", url)
+	matches := make(map[string]string)
+	for _, m := range parsed.Matches {
+		key := normalizeTagKey(m.Tag)
+		userTag := strings.TrimSpace(m.UserTag)
+		if !recommended[key] || !existing[userTag] {
+			continue
+		}
+		if _, ok := matches[key]; !ok {
+			matches[key] = userTag
 		}
 	}
 
-	// JSON 배열 파싱
-	var tags []string
-	err = json.Unmarshal([]byte(responseText), &tags)
+	return matches, nil
+}
+
+// recommendTags 프롬프트 템플릿(aiprompt)으로 LLM 에 태그 추천을 요청합니다
+// 템플릿에는 URL, Content 가 주어집니다
+func recommendTags(provider llm.LLMProvider, prompt *aiprompt.AIPrompt, content string, url string) ([]string, error) {
+	systemPrompt, userPrompt, err := prompt.Render(aiTagPromptData{
+		URL:     url,
+		Content: content,
+	})
 	if err != nil {
-		// JSON 파싱 실패 시 응답에서 태그 추출 시도
-		// 예: "["태그1", "태그2"]" 형식이 아닌 경우 처리
-		log.Printf("[AI 태그 추천] OpenAI 응답 파싱 실패 (url=%s, response=%s, err=%v)", url, responseText, err)
-		return nil, fmt.Errorf("failed to parse AI response as JSON: %v, response: %s", err, responseText)
+		log.Printf("[AI 태그 추천] 프롬프트 렌더링 실패 (version=%s): %v", prompt.Version, err)
+		return nil, err
 	}
 
-	// 최대 5개로 제한
-	if len(tags) > 5 {
-		tags = tags[:5]
+	temperature := prompt.Temperature
+	if temperature == 0 {
+		temperature = 0.3 // 일관성을 위해 낮은 temperature 사용
+	}
+	maxTokens := prompt.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 200
+	}
+
+	ctx := context.Background()
+	req := llm.ChatRequest{
+		Messages: []llm.Message{
+			{
+				Role:    llm.RoleSystem,
+				Content: systemPrompt,
+			},
+			{
+				Role:    llm.RoleUser,
+				Content: userPrompt,
+			},
+		},
+		Temperature:    temperature,
+		MaxTokens:      maxTokens,
+		ResponseFormat: &aiTagResponseFormat,
+	}
+
+	// JSON 배열 파싱 (structured output 미지원 제공자는 설명이 섞인 응답에서 배열을 찾고, 실패하면 한 번 다시 요청)
+	var tags []string
+	_, err = llm.ChatJSON(ctx, provider, req, &tags)
+	if err != nil {
+		log.Printf("[AI 태그 추천] LLM 호출 실패 (provider=%s, url=%s): %v", provider.Name(), url, err)
+		return nil, err
 	}
 
 	return tags, nil
 }
+
+// filterRecommendedTags 정책(ai_tag_policy.md)을 어기는 태그와 중복을 제외하고 최대 5개로 제한합니다
+func filterRecommendedTags(tags []string, url string) ([]string, []tagpolicy.Rejected) {
+	kept, rejected := tagpolicy.Filter(tags, url)
+	for _, r := range rejected {
+		log.Printf("[AI 태그 추천] 정책 위반 태그 제외 (url=%s, tag=%s, reasons=%s)", url, r.Tag, strings.Join(r.Reasons, ","))
+	}
+
+	return kept, rejected
+}
@@ -0,0 +1,220 @@
+package link
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"joosum-backend/app/aiprompt"
+	"joosum-backend/pkg/llm"
+)
+
+const testArticleHTML = `<html><head>
+<title>React 서버 컴포넌트 정리</title>
+<meta property="og:description" content="React 서버 컴포넌트와 스트리밍 렌더링을 정리한 글입니다.">
+</head><body><article><p>서버 컴포넌트는 서버에서만 렌더링되는 컴포넌트로, 번들 크기를 줄이고 데이터 패칭을 단순하게 만듭니다.</p></article></body></html>`
+
+func testTagPrompt(t *testing.T) *aiprompt.AIPrompt {
+	t.Helper()
+
+	prompts, err := aiprompt.LoadPromptFiles(aiprompt.NameAITags, "")
+	if err != nil {
+		t.Fatalf("내장 프롬프트 로드 실패: %v", err)
+	}
+	prompt, ok := prompts["v2"]
+	if !ok {
+		t.Fatalf("내장 프롬프트 v2 없음")
+	}
+	return &prompt
+}
+
+func TestRecommendTagsFromHTML(t *testing.T) {
+	tests := []struct {
+		name         string
+		responses    []string
+		userTags     []string
+		want         []AIRecommendedTag
+		wantRejected []string
+		wantRequests int
+	}{
+		{
+			name:         "JSON 배열 응답",
+			responses:    []string{`["React", "서버컴포넌트", "스트리밍"]`},
+			want:         []AIRecommendedTag{{Name: "React"}, {Name: "서버컴포넌트"}, {Name: "스트리밍"}},
+			wantRequests: 1,
+		},
+		{
+			name:         "structured output 으로 감싼 응답",
+			responses:    []string{`{"tags": ["React", "렌더링"]}`},
+			want:         []AIRecommendedTag{{Name: "React"}, {Name: "렌더링"}},
+			wantRequests: 1,
+		},
+		{
+			name:         "설명과 코드 블록이 섞인 응답",
+			responses:    []string{"추천 태그입니다.\n```json\n[\"React\", \"번들\",]\n```"},
+			want:         []AIRecommendedTag{{Name: "React"}, {Name: "번들"}},
+			wantRequests: 1,
+		},
+		{
+			name:         "파싱 실패하면 한 번 다시 요청",
+			responses:    []string{"태그: React, 렌더링", `["React", "렌더링"]`},
+			want:         []AIRecommendedTag{{Name: "React"}, {Name: "렌더링"}},
+			wantRequests: 2,
+		},
+		{
+			name:         "정책을 어긴 태그 제외",
+			responses:    []string{`["React", "example.com", "2024년", "유용한", "서버 컴포넌트 쓰는 방법"]`},
+			want:         []AIRecommendedTag{{Name: "React"}},
+			wantRejected: []string{"example.com", "2024년", "유용한", "서버 컴포넌트 쓰는 방법"},
+			wantRequests: 1,
+		},
+		{
+			name:         "표기만 다른 기존 태그는 LLM 에 묻지 않고 맞춤",
+			responses:    []string{`["react", "Front-End"]`},
+			userTags:     []string{"frontend", "React", "디자인"},
+			want:         []AIRecommendedTag{{Name: "frontend", IsExisting: true}, {Name: "React", IsExisting: true}},
+			wantRequests: 1,
+		},
+		{
+			name:         "같은 의미의 기존 태그로 맞추고 먼저 정렬",
+			responses:    []string{`["서버컴포넌트", "리액트", "Front-End"]`, `{"matches": [{"tag": "리액트", "userTag": "React"}]}`},
+			userTags:     []string{"frontend", "React", "디자인"},
+			want:         []AIRecommendedTag{{Name: "frontend", IsExisting: true}, {Name: "React", IsExisting: true}, {Name: "서버컴포넌트"}},
+			wantRequests: 2,
+		},
+		{
+			name:         "추천하지 않은 태그나 없는 기존 태그로 맞춘 응답은 무시",
+			responses:    []string{`["서버컴포넌트", "리액트"]`, `{"matches": [{"tag": "Vue", "userTag": "React"}, {"tag": "서버컴포넌트", "userTag": "RSC"}]}`},
+			userTags:     []string{"React", "디자인"},
+			want:         []AIRecommendedTag{{Name: "서버컴포넌트"}, {Name: "리액트"}},
+			wantRequests: 2,
+		},
+		{
+			name:         "기존 태그 맞춤에 실패하면 표기만 비교",
+			responses:    []string{`["리액트", "Front-End"]`, "모르겠습니다", "모르겠습니다"},
+			userTags:     []string{"frontend", "React"},
+			want:         []AIRecommendedTag{{Name: "frontend", IsExisting: true}, {Name: "리액트"}},
+			wantRequests: 3,
+		},
+	}
+
+	prompt := testTagPrompt(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			provider := &llm.FakeProvider{Respond: func(req llm.ChatRequest) (string, error) {
+				response := tt.responses[calls]
+				calls++
+				return response, nil
+			}}
+
+			got, rejected, err := RecommendTagsFromHTML(provider, prompt, strings.NewReader(testArticleHTML), "https://example.com/react-rsc", tt.userTags)
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tags = %+v, want %+v", got, tt.want)
+			}
+
+			var rejectedTags []string
+			for _, r := range rejected {
+				rejectedTags = append(rejectedTags, r.Tag)
+			}
+			if !reflect.DeepEqual(rejectedTags, tt.wantRejected) {
+				t.Errorf("rejected = %v, want %v", rejectedTags, tt.wantRejected)
+			}
+
+			requests := provider.Requests()
+			if len(requests) != tt.wantRequests {
+				t.Fatalf("요청 수 = %d, want %d", len(requests), tt.wantRequests)
+			}
+			// 태그 추천 프롬프트에는 본문만 들어가고, 기존 태그는 맞춤 요청에만 들어감 (캐시를 사용자끼리 나눠 씀)
+			userPrompt := requests[0].Messages[1].Content
+			if !strings.Contains(userPrompt, "React 서버 컴포넌트 정리") {
+				t.Errorf("프롬프트에 본문 없음: %s", userPrompt)
+			}
+			if len(tt.userTags) > 0 && strings.Contains(userPrompt, strings.Join(tt.userTags, ", ")) {
+				t.Errorf("태그 추천 프롬프트에 기존 태그가 있음: %s", userPrompt)
+			}
+			if len(tt.userTags) > 0 && len(requests) > 1 {
+				matchPrompt := requests[1].Messages[1].Content
+				if !strings.Contains(matchPrompt, strings.Join(tt.userTags, ", ")) {
+					t.Errorf("맞춤 프롬프트에 기존 태그 없음: %s", matchPrompt)
+				}
+			}
+		})
+	}
+}
+
+func TestRecommendTagsFromHTMLErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		provider *llm.FakeProvider
+	}{
+		{
+			name:     "LLM 호출 실패",
+			html:     testArticleHTML,
+			provider: &llm.FakeProvider{Respond: func(req llm.ChatRequest) (string, error) { return "", errors.New("unavailable") }},
+		},
+		{
+			name:     "다시 요청해도 JSON 이 아님",
+			html:     testArticleHTML,
+			provider: llm.NewFakeProvider("태그를 찾지 못했습니다"),
+		},
+		{
+			name:     "본문을 추출할 수 없는 페이지",
+			html:     "<html><body></body></html>",
+			provider: llm.NewFakeProvider(`["React"]`),
+		},
+	}
+
+	prompt := testTagPrompt(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := RecommendTagsFromHTML(tt.provider, prompt, strings.NewReader(tt.html), "https://example.com/react-rsc", nil)
+			if err == nil {
+				t.Errorf("err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestPersonalizeTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		recommended []string
+		userTags    []string
+		want        []AIRecommendedTag
+	}{
+		{
+			name:        "기존 태그 없음",
+			recommended: []string{"React", "Next.js"},
+			want:        []AIRecommendedTag{{Name: "React"}, {Name: "Next.js"}},
+		},
+		{
+			name:        "대소문자, 공백, 구분자만 다르면 기존 태그 사용",
+			recommended: []string{"ux research", "Next-JS"},
+			userTags:    []string{"NextJS", "UX Research"},
+			want:        []AIRecommendedTag{{Name: "NextJS", IsExisting: true}, {Name: "UX Research", IsExisting: true}},
+		},
+		{
+			name:        "추천 안의 중복 제거",
+			recommended: []string{"React", "react", " React "},
+			want:        []AIRecommendedTag{{Name: "React"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := personalizeTags(tt.recommended, tt.userTags)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("personalizeTags = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -146,13 +146,23 @@ func (h LinkBookHandler) UpdateLinkBook(c *gin.Context) {
 // @Tags 링크북
 // @Summary 링크북 삭제
 // @Description 링크북과 모든 링크들을 삭제 (기본 링크북이라면 링크들만 삭제)
+// @Description `moveTo` 를 전달하면 링크들을 삭제하지 않고 해당 링크북으로 옮깁니다. 기본 링크북으로 옮기려면 root 를 넣어주세요.
 // @Param        linkBookId   path      string  true  "LinkBookId"
+// @Param request query link.LinkBookDeleteReq false "request"
 // @Success 200 {object} link.LinkBookDeleteRes
+// @Failure 400 {object} util.APIError "같은 링크북으로 옮기려는 경우 반환합니다."
+// @Failure 404 {object} util.APIError "링크북을 찾을 수 없는 경우 반환합니다."
 // @Security ApiKeyAuth
 // @Router /link-books/{linkBookId} [delete]
 func (h LinkBookHandler) DeleteLinkBook(c *gin.Context) {
 	linkBookId := c.Param("linkBookId")
 
+	var req LinkBookDeleteReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		util.SendError(c, http.StatusBadRequest, util.CodeInvalidRequestBody)
+		return
+	}
+
 	currentUser, exists := c.Get("user")
 	if !exists {
 		// 401 Unauthorized
@@ -162,12 +172,113 @@ func (h LinkBookHandler) DeleteLinkBook(c *gin.Context) {
 
 	userId := currentUser.(*user.User).UserId
 
-	res, err := h.linkBookUsecase.DeleteLinkBookWithLinks(userId, linkBookId)
+	var res *LinkBookDeleteRes
+	var err error
+	if req.MoveTo == "" {
+		res, err = h.linkBookUsecase.DeleteLinkBookWithLinks(userId, linkBookId)
+	} else {
+		res, err = h.linkBookUsecase.DeleteLinkBookAndMoveLinks(userId, linkBookId, req.MoveTo)
+	}
+	if err != nil {
+		sendLinkBookMoveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// MergeLinkBook
+// @Tags 링크북
+// @Summary 링크북 합치기
+// @Description 링크북의 모든 링크를 대상 링크북으로 옮기고 링크북을 삭제합니다. (기본 링크북이라면 링크들만 이동)
+// @Param        linkBookId   path      string  true  "LinkBookId"
+// @Param request body link.LinkBookMergeReq true "request"
+// @Success 200 {object} link.LinkBookMergeRes
+// @Failure 400 {object} util.APIError "요청 본문이 유효하지 않거나 같은 링크북을 합치려는 경우 반환합니다."
+// @Failure 404 {object} util.APIError "링크북을 찾을 수 없는 경우 반환합니다."
+// @Security ApiKeyAuth
+// @Router /link-books/{linkBookId}/merge [post]
+func (h LinkBookHandler) MergeLinkBook(c *gin.Context) {
+	var req LinkBookMergeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.SendError(c, http.StatusBadRequest, util.CodeInvalidRequestBody)
+		return
+	}
+
+	if req.TargetLinkBookId == "" {
+		util.SendError(c, http.StatusBadRequest, util.CodeMissingParameter)
+		return
+	}
+
+	currentUser, exists := c.Get("user")
+	if !exists {
+		// 401 Unauthorized
+		util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+		return
+	}
+
+	userId := currentUser.(*user.User).UserId
+
+	linkBookId := c.Param("linkBookId")
+
+	res, err := h.linkBookUsecase.MergeLinkBooks(userId, linkBookId, req.TargetLinkBookId)
+	if err != nil {
+		sendLinkBookMoveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetLinkBookNameDrift
+// @Tags 링크북
+// @Summary 링크북 이름 불일치 조회
+// @Description 링크에 저장된 링크북 이름(link_book_name)이 실제 링크북 제목과 다른 링크들을 링크북별로 집계합니다.
+// @Description userId 를 주면 해당 사용자만, 없으면 전체 사용자를 100명씩 나누어 처리합니다.
+// @Param userId query []string false "사용자 아이디 (여러 개 가능)" collectionFormat(multi)
+// @Success 200 {object} link.LinkBookNameDriftRes
+// @Failure 500 {object} util.APIError "집계 과정에서 오류가 발생한 경우 반환합니다."
+// @Security InternalApiKeyAuth
+// @Router /link-book-name-drift [get]
+func (h LinkBookHandler) GetLinkBookNameDrift(c *gin.Context) {
+	res, err := h.linkBookUsecase.GetLinkBookNameDrift(c.QueryArray("userId"))
 	if err != nil {
-		// 500 Internal Server Error
 		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
 		return
 	}
 
 	c.JSON(http.StatusOK, res)
 }
+
+// RepairLinkBookNameDrift
+// @Tags 링크북
+// @Summary 링크북 이름 불일치 복구
+// @Description 링크에 저장된 링크북 이름을 실제 링크북 제목으로 맞춥니다. 링크북이 없는 링크는 복구하지 않습니다.
+// @Description userId 를 주면 해당 사용자만, 없으면 전체 사용자를 100명씩 나누어 처리합니다.
+// @Param userId query []string false "사용자 아이디 (여러 개 가능)" collectionFormat(multi)
+// @Success 200 {object} link.LinkBookNameRepairRes
+// @Failure 500 {object} util.APIError "복구 과정에서 오류가 발생한 경우 반환합니다."
+// @Security InternalApiKeyAuth
+// @Router /link-book-name-drift/repair [post]
+func (h LinkBookHandler) RepairLinkBookNameDrift(c *gin.Context) {
+	res, err := h.linkBookUsecase.RepairLinkBookNameDrift(c.QueryArray("userId"))
+	if err != nil {
+		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// sendLinkBookMoveError 는 링크 이동 과정의 오류를 응답 코드로 변환합니다.
+func sendLinkBookMoveError(c *gin.Context, err error) {
+	switch err {
+	case util.ErrLinkBookNotFound:
+		util.SendError(c, http.StatusNotFound, util.CodeLinkBookNotFound)
+	case util.ErrSameLinkBook:
+		util.SendError(c, http.StatusBadRequest, util.CodeSameLinkBook)
+	default:
+		// 500 Internal Server Error
+		util.SendError(c, http.StatusInternalServerError, util.CodeInternalServerError)
+	}
+}
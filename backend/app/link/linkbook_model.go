@@ -3,6 +3,8 @@ package link
 import (
 	"context"
 	"joosum-backend/pkg/db"
+	"log"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -31,7 +33,9 @@ type LinkBookRes struct {
 	CreatedAt       time.Time `bson:"created_at" json:"createdAt"`
 	LastSavedAt     time.Time `bson:"last_saved_at" json:"lastSavedAt"`
 	UserId          string    `bson:"user_id" example:"User-0767d6af-a802-469c-9505-5ca91e03b354" json:"userId"`
-	LinkCount       int64     `json:"linkCount"`
+	LinkCount       int64     `bson:"link_count" json:"linkCount"`
+	UnreadLinkCount int64     `bson:"unread_link_count" json:"unreadLinkCount"`
+	LatestThumbnail *string   `bson:"latest_thumbnail_url" json:"latestThumbnailURL"`
 	IsDefault       string    `bson:"is_default" json:"isDefault"`
 }
 
@@ -42,8 +46,38 @@ type LinkBookCreateReq struct {
 	Illustration    *string `json:"illustration"`
 }
 
+type LinkBookDeleteReq struct {
+	// 링크를 옮길 링크북 아이디. 기본 링크북은 root, 비어있으면 링크를 삭제
+	MoveTo string `form:"moveTo" example:"root"`
+}
+
 type LinkBookDeleteRes struct {
 	DeletedLinks int64 `json:"deletedLinks"`
+	MovedLinks   int64 `json:"movedLinks"`
+}
+
+type LinkBookMergeReq struct {
+	TargetLinkBookId string `json:"targetLinkBookId" example:"LinkBook-0767d6af-a802-469c-9505-5ca91e03b354" validate:"required"`
+}
+
+type LinkBookMergeRes struct {
+	MovedLinks int64 `json:"movedLinks"`
+}
+
+// LinkBookNameDrift 는 링크에 저장된 링크북 이름이 실제 링크북 제목과 다른 링크북별 집계입니다.
+type LinkBookNameDrift struct {
+	LinkBookId   string  `bson:"_id" json:"linkBookId"`
+	Title        *string `bson:"title" json:"title"` // 링크북이 삭제되어 없으면 null
+	DriftedLinks int64   `bson:"drifted_links" json:"driftedLinks"`
+}
+
+type LinkBookNameDriftRes struct {
+	Drifts            []LinkBookNameDrift `json:"drifts"`
+	TotalDriftedLinks int64               `json:"totalDriftedLinks"`
+}
+
+type LinkBookNameRepairRes struct {
+	RepairedLinks int64 `json:"repairedLinks"`
 }
 
 type LinkBook struct {
@@ -58,10 +92,8 @@ type LinkBook struct {
 	IsDefault       string    `bson:"is_default" json:"isDefault"`
 }
 
-func (LinkBookModel) GetLinkBooks(req LinkBookListReq, userId string) ([]LinkBookRes, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
+// linkBookSort 는 링크북 목록 정렬 조건을 만듭니다.
+func linkBookSort(req LinkBookListReq) bson.D {
 	// 정렬 순서 디폴트: 생성 순
 	if req.Sort == "" || req.Sort == "create_at" {
 		req.Sort = "created_at"
@@ -87,9 +119,17 @@ func (LinkBookModel) GetLinkBooks(req LinkBookListReq, userId string) ([]LinkBoo
 	if req.Sort == "last_saved_at" {
 		sort = append(sort, bson.E{"created_at", -1}) // 업데이트 순이 같다면 생성 순으로 정렬
 	}
+
+	return sort
+}
+
+func (LinkBookModel) GetLinkBooks(req LinkBookListReq, userId string) ([]LinkBookRes, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
 	// 폴더 정렬
 	opts := options.Find()
-	opts.SetSort(sort)
+	opts.SetSort(linkBookSort(req))
 	cur, err := db.LinkBookCollection.Find(ctx, map[string]string{"user_id": userId}, opts)
 	if err != nil {
 		return nil, err
@@ -114,6 +154,107 @@ func (LinkBookModel) GetLinkBooks(req LinkBookListReq, userId string) ([]LinkBoo
 	return linkBooks, nil
 }
 
+// GetLinkBooksWithLinkStats 는 링크북 목록을 링크 수, 읽지 않은 링크 수, 최근 썸네일과 함께 한 번의 aggregation 으로 조회합니다.
+func (LinkBookModel) GetLinkBooksWithLinkStats(req LinkBookListReq, userId string) ([]LinkBookRes, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	linkCollection := db.LinkCollection.Name()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userId}}},
+		// 링크북별 링크 수, 읽지 않은 링크 수
+		{{Key: "$lookup", Value: bson.M{
+			"from": linkCollection,
+			"let":  bson.M{"linkBookId": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$link_book_id", "$$linkBookId"}}}},
+				bson.M{"$group": bson.M{
+					"_id":    nil,
+					"count":  bson.M{"$sum": 1},
+					"unread": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$read_count", 0}}, 1, 0}}},
+				}},
+			},
+			"as": "link_stats",
+		}}},
+		// 썸네일이 있는 가장 최근 링크
+		{{Key: "$lookup", Value: bson.M{
+			"from": linkCollection,
+			"let":  bson.M{"linkBookId": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"$expr":         bson.M{"$eq": bson.A{"$link_book_id", "$$linkBookId"}},
+					"thumbnail_url": bson.M{"$nin": bson.A{"", nil}},
+				}},
+				bson.M{"$sort": bson.M{"created_at": db.Desc}},
+				bson.M{"$limit": 1},
+				bson.M{"$project": bson.M{"_id": 0, "thumbnail_url": 1}},
+			},
+			"as": "latest_link",
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"link_count":           bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$link_stats.count", 0}}, 0}},
+			"unread_link_count":    bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$link_stats.unread", 0}}, 0}},
+			"latest_thumbnail_url": bson.M{"$arrayElemAt": bson.A{"$latest_link.thumbnail_url", 0}},
+		}}},
+		{{Key: "$project", Value: bson.M{"link_stats": 0, "latest_link": 0}}},
+		{{Key: "$sort", Value: linkBookSort(req)}},
+	}
+
+	cur, err := db.LinkBookCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	linkBooks := []LinkBookRes{}
+	if err := cur.All(ctx, &linkBooks); err != nil {
+		return nil, err
+	}
+
+	return linkBooks, nil
+}
+
+// GetUserLinkBookCount 는 사용자의 링크북(폴더) 수를 반환합니다.
+func (LinkBookModel) GetUserLinkBookCount(userId string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := db.LinkBookCollection.CountDocuments(ctx, bson.M{"user_id": userId})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetLinkBookTitles 는 사용자의 링크북 아이디별 제목을 반환합니다.
+func (LinkBookModel) GetLinkBookTitles(userId string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "title": 1})
+	cur, err := db.LinkBookCollection.Find(ctx, bson.M{"user_id": userId}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	titles := map[string]string{}
+	for cur.Next(ctx) {
+		var linkBook LinkBook
+		if err := cur.Decode(&linkBook); err != nil {
+			return nil, err
+		}
+		titles[linkBook.LinkBookId] = linkBook.Title
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return titles, nil
+}
+
 func (LinkBookModel) CreateLinkBook(linkBook LinkBook) (*LinkBook, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -209,6 +350,78 @@ func (LinkBookModel) UpdateLinkBookLastSavedAt(linkBookId string) error {
 	return result
 }
 
+// MoveLinksAndDeleteLinkBook 은 source 링크북의 링크를 target 링크북으로 옮기고(링크북 이름 포함) deleteSource 면 source 링크북을 삭제합니다.
+// 링크북 삭제, 링크 이동, target 의 마지막 저장 시각 변경을 한 트랜잭션으로 처리하므로 중간에 실패하면 아무것도 바뀌지 않습니다.
+// 트랜잭션을 지원하지 않는 단독 실행 mongod 에서는 링크를 먼저 옮긴 뒤 링크북을 삭제합니다.
+// 이때 중간에 실패하면 일부 링크만 옮겨질 수 있지만, 같은 요청을 다시 보내면 남은 링크를 옮기고 삭제를 마칩니다.
+func (LinkBookModel) MoveLinksAndDeleteLinkBook(userId string, source, target *LinkBook, deleteSource bool) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	session, err := db.LinkBookCollection.Database().Client().StartSession()
+	if err != nil {
+		return 0, err
+	}
+	defer session.EndSession(ctx)
+
+	moved, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		// 링크북을 먼저 삭제해 같은 링크북을 동시에 옮기거나 삭제하는 요청과 충돌하면 트랜잭션을 다시 시도하게 함
+		if deleteSource {
+			if _, err := db.LinkBookCollection.DeleteOne(sc, bson.M{"_id": source.LinkBookId}); err != nil {
+				return nil, err
+			}
+		}
+
+		return moveLinksToLinkBook(sc, userId, source, target)
+	})
+	if isTransactionNotSupported(err) {
+		log.Printf("[링크북 이동] 트랜잭션 미지원, 링크 이동 후 링크북 삭제 (linkBookId=%s)", source.LinkBookId)
+
+		movedCount, err := moveLinksToLinkBook(ctx, userId, source, target)
+		if err != nil {
+			return 0, err
+		}
+
+		if deleteSource {
+			if _, err := db.LinkBookCollection.DeleteOne(ctx, bson.M{"_id": source.LinkBookId}); err != nil {
+				return 0, err
+			}
+		}
+
+		return movedCount, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return moved.(int64), nil
+}
+
+// moveLinksToLinkBook 은 source 링크북의 링크를 target 링크북으로 옮기고, 옮긴 링크가 있으면 target 의 마지막 저장 시각을 바꿉니다.
+func moveLinksToLinkBook(ctx context.Context, userId string, source, target *LinkBook) (int64, error) {
+	filter := bson.M{"user_id": userId, "link_book_id": source.LinkBookId}
+	update := bson.M{"$set": bson.M{"link_book_id": target.LinkBookId, "link_book_name": target.Title}}
+	result, err := db.LinkCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	if result.ModifiedCount > 0 {
+		_, err = db.LinkBookCollection.UpdateOne(ctx, bson.M{"_id": target.LinkBookId}, bson.M{"$set": bson.M{"last_saved_at": time.Now()}})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// isTransactionNotSupported 는 트랜잭션을 지원하지 않는 배포(레플리카 셋이 아닌 단독 실행 mongod)에서 난 오류인지 확인합니다.
+func isTransactionNotSupported(err error) bool {
+	cmdErr, ok := err.(mongo.CommandError)
+	return ok && cmdErr.Code == 20 && strings.Contains(cmdErr.Message, "Transaction numbers")
+}
+
 func (LinkBookModel) DeleteLinkBook(linkBookId string) (*mongo.DeleteResult, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -0,0 +1,195 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"joosum-backend/pkg/db"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 벤치마크용 사용자의 링크북 수와 링크북별 링크 수
+const (
+	benchLinkBooks        = 30
+	benchLinksPerLinkBook = 20
+)
+
+// setupLinkBookBenchmark 는 MONGODB_TEST_URI 의 임시 DB 에 링크북과 링크를 만들고 사용자 아이디를 반환합니다.
+// MONGODB_TEST_URI 가 없으면 벤치마크를 건너뜁니다. 임시 DB 는 벤치마크가 끝나면 지웁니다.
+//
+//	MONGODB_TEST_URI=mongodb://localhost:27017 go test ./app/link -run '^$' -bench LinkBook
+func setupLinkBookBenchmark(b *testing.B) string {
+	b.Helper()
+
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		b.Skip("MONGODB_TEST_URI 가 없어 건너뜀")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		b.Fatalf("MongoDB 연결 실패: %v", err)
+	}
+
+	dbName := fmt.Sprintf("joosum_bench_%d", time.Now().UnixNano())
+	db.InitLinkCollection(client, dbName)
+	db.InitLinkBookCollection(client, dbName)
+
+	b.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		client.Database(dbName).Drop(ctx)
+		client.Disconnect(ctx)
+	})
+
+	userId := "User-bench"
+	now := time.Now()
+
+	var linkBooks []interface{}
+	var links []interface{}
+	for i := 0; i < benchLinkBooks; i++ {
+		linkBookId := fmt.Sprintf("LinkBook-bench-%d", i)
+		isDefault := "n"
+		if i == 0 {
+			isDefault = "y"
+		}
+		linkBooks = append(linkBooks, LinkBook{
+			LinkBookId:  linkBookId,
+			Title:       fmt.Sprintf("폴더 %d", i),
+			CreatedAt:   now.Add(time.Duration(i) * time.Minute),
+			LastSavedAt: now,
+			UserId:      userId,
+			IsDefault:   isDefault,
+		})
+
+		for j := 0; j < benchLinksPerLinkBook; j++ {
+			links = append(links, Link{
+				LinkId:       fmt.Sprintf("Link-bench-%d-%d", i, j),
+				URL:          fmt.Sprintf("https://example.com/%d/%d", i, j),
+				UserID:       userId,
+				Title:        fmt.Sprintf("링크 %d-%d", i, j),
+				LinkBookId:   linkBookId,
+				ThumbnailURL: fmt.Sprintf("https://example.com/%d/%d.png", i, j),
+				ReadCount:    j % 2,
+				CreatedAt:    now.Add(time.Duration(j) * time.Second),
+			})
+		}
+	}
+
+	if _, err := db.LinkBookCollection.InsertMany(ctx, linkBooks); err != nil {
+		b.Fatalf("링크북 생성 실패: %v", err)
+	}
+	if _, err := db.LinkCollection.InsertMany(ctx, links); err != nil {
+		b.Fatalf("링크 생성 실패: %v", err)
+	}
+
+	return userId
+}
+
+// 한 번의 aggregation 으로 링크북 목록과 링크 수, 읽지 않은 링크 수, 최근 썸네일을 조회
+func BenchmarkGetLinkBooksWithLinkStats(b *testing.B) {
+	userId := setupLinkBookBenchmark(b)
+	model := LinkBookModel{}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		linkBooks, err := model.GetLinkBooksWithLinkStats(LinkBookListReq{}, userId)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(linkBooks) != benchLinkBooks {
+			b.Fatalf("링크북 수 = %d, want %d", len(linkBooks), benchLinkBooks)
+		}
+	}
+}
+
+// 비교용: 링크북 목록을 불러온 뒤 링크북마다 링크 수를 세는 방식 (링크북 수 + 2 번 조회)
+func BenchmarkGetLinkBooksWithLinkCountQueries(b *testing.B) {
+	userId := setupLinkBookBenchmark(b)
+	linkBookModel := LinkBookModel{}
+	linkModel := LinkModel{}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		linkBooks, err := linkBookModel.GetLinkBooks(LinkBookListReq{}, userId)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for j := range linkBooks {
+			count, err := linkModel.GetLinkBookLinkCount(linkBooks[j].LinkBookId)
+			if err != nil {
+				b.Fatal(err)
+			}
+			linkBooks[j].LinkCount = count
+		}
+		if _, err := linkModel.GetUserLinkCount(userId); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// 내 정보(GetMe)의 폴더 수: 링크북 수만 센다
+func BenchmarkGetUserLinkBookCount(b *testing.B) {
+	userId := setupLinkBookBenchmark(b)
+	model := LinkBookModel{}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		count, err := model.GetUserLinkBookCount(userId)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if count != benchLinkBooks {
+			b.Fatalf("링크북 수 = %d, want %d", count, benchLinkBooks)
+		}
+	}
+}
+
+// 비교용: 링크북을 모두 불러와 len 으로 세는 방식
+func BenchmarkGetLinkBooksForCount(b *testing.B) {
+	userId := setupLinkBookBenchmark(b)
+	model := LinkBookModel{}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		linkBooks, err := model.GetLinkBooks(LinkBookListReq{}, userId)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(linkBooks) != benchLinkBooks {
+			b.Fatalf("링크북 수 = %d, want %d", len(linkBooks), benchLinkBooks)
+		}
+	}
+}
+
+func TestIsTransactionNotSupported(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "단독 실행 mongod", err: mongo.CommandError{Code: 20, Name: "IllegalOperation", Message: "Transaction numbers are only allowed on a replica set member or mongos"}, want: true},
+		{name: "다른 IllegalOperation", err: mongo.CommandError{Code: 20, Name: "IllegalOperation", Message: "cannot write to capped collection"}},
+		{name: "쓰기 충돌", err: mongo.CommandError{Code: 112, Name: "WriteConflict", Message: "Transaction numbers"}},
+		{name: "다른 오류", err: errors.New("Transaction numbers are only allowed on a replica set member or mongos")},
+		{name: "오류 없음", err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransactionNotSupported(tt.err); got != tt.want {
+				t.Errorf("isTransactionNotSupported(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package link
 
 import (
+	"log"
+	"sort"
+
 	"gopkg.in/errgo.v2/errors"
+	"joosum-backend/app/user"
 	"joosum-backend/pkg/util"
 	"time"
 
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type LinkBookUsecase struct {
@@ -30,19 +35,12 @@ func (u LinkBookUsecase) GetLinkbooksForMainPage(userId string) ([]LinkBookRes,
 }
 
 func (u LinkBookUsecase) GetLinkBooks(req LinkBookListReq, userId string) (*LinkBookListRes, error) {
-	linkBooks, err := u.linkBookModel.GetLinkBooks(req, userId)
+	// 링크북별 링크 수는 aggregation 한 번으로 함께 조회
+	linkBooks, err := u.linkBookModel.GetLinkBooksWithLinkStats(req, userId)
 	if err != nil {
 		return nil, err
 	}
 
-	for i, linkBook := range linkBooks {
-		linkCount, err := u.linkModel.GetLinkBookLinkCount(linkBook.LinkBookId)
-		if err != nil {
-			return nil, err
-		}
-		linkBooks[i].LinkCount = linkCount
-	}
-
 	totalLinkCount, err := u.linkModel.GetUserLinkCount(userId)
 	if err != nil {
 		return nil, err
@@ -149,10 +147,201 @@ func (u LinkBookUsecase) DeleteLinkBookWithLinks(userId, linkBookId string) (*Li
 		}
 	}
 
+	linkIds, err := u.linkModel.GetLinkIdsByLinkBookId(userId, linkBookId)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := u.linkModel.DeleteAllLinksByLinkBookId(userId, linkBookId)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := u.linkModel.DeleteLinkDerivedData(linkIds); err != nil {
+		log.Printf("[링크북 삭제] 본문/임베딩 삭제 실패 (linkBookId=%s): %v", linkBookId, err)
+	}
+
 	return &LinkBookDeleteRes{DeletedLinks: result.DeletedCount}, nil
 }
+
+// getOwnedLinkBook 은 사용자가 소유한 링크북을 조회합니다. root 나 빈 스트링이면 기본 링크북을 반환합니다.
+func (u LinkBookUsecase) getOwnedLinkBook(userId, linkBookId string) (*LinkBook, error) {
+	var linkBook *LinkBook
+	var err error
+	if linkBookId == "root" || linkBookId == "" {
+		linkBook, err = u.linkBookModel.GetDefaultLinkBook(userId)
+	} else {
+		linkBook, err = u.linkBookModel.GetLinkBookById(linkBookId)
+	}
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, util.ErrLinkBookNotFound
+		}
+		return nil, err
+	}
+
+	if linkBook.UserId != userId {
+		return nil, util.ErrLinkBookNotFound
+	}
+
+	return linkBook, nil
+}
+
+// moveLinks 는 source 링크북의 링크를 target 링크북으로 옮기고, source 가 기본 링크북이 아니면 삭제합니다.
+func (u LinkBookUsecase) moveLinks(userId string, source, target *LinkBook) (int64, error) {
+	if source.LinkBookId == target.LinkBookId {
+		return 0, util.ErrSameLinkBook
+	}
+
+	// 링크북 삭제와 링크 이동을 한 트랜잭션으로 처리 (트랜잭션 미지원이면 이동 후 삭제). 기본 링크북 폴더는 삭제하지 않음
+	deleteSource := source.IsDefault != "y"
+	movedCount, err := u.linkBookModel.MoveLinksAndDeleteLinkBook(userId, source, target, deleteSource)
+	if err != nil {
+		return 0, err
+	}
+
+	// 옮기는 중에 삭제된 링크북으로 저장된 링크가 있으면 마저 옮김
+	if deleteSource {
+		lateCount, err := u.linkModel.MoveLinksToLinkBook(userId, source.LinkBookId, target.LinkBookId, target.Title)
+		if err != nil {
+			return 0, err
+		}
+		movedCount += lateCount
+	}
+
+	return movedCount, nil
+}
+
+// DeleteLinkBookAndMoveLinks 는 링크북을 삭제하고 링크들은 moveTo 링크북으로 옮깁니다.
+func (u LinkBookUsecase) DeleteLinkBookAndMoveLinks(userId, linkBookId, moveTo string) (*LinkBookDeleteRes, error) {
+	source, err := u.getOwnedLinkBook(userId, linkBookId)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := u.getOwnedLinkBook(userId, moveTo)
+	if err != nil {
+		return nil, err
+	}
+
+	movedCount, err := u.moveLinks(userId, source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LinkBookDeleteRes{MovedLinks: movedCount}, nil
+}
+
+// MergeLinkBooks 는 링크북의 링크를 대상 링크북으로 합치고 링크북을 삭제합니다.
+func (u LinkBookUsecase) MergeLinkBooks(userId, linkBookId, targetLinkBookId string) (*LinkBookMergeRes, error) {
+	source, err := u.getOwnedLinkBook(userId, linkBookId)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := u.getOwnedLinkBook(userId, targetLinkBookId)
+	if err != nil {
+		return nil, err
+	}
+
+	movedCount, err := u.moveLinks(userId, source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LinkBookMergeRes{MovedLinks: movedCount}, nil
+}
+
+// 링크북 이름 불일치를 한 번에 집계하는 사용자 수
+const linkBookNameDriftBatchSize = 100
+
+// GetLinkBookNameDrift 는 링크에 저장된 링크북 이름이 실제 제목과 어긋난 현황을 반환합니다.
+// userIds 가 없으면 전체 사용자를 linkBookNameDriftBatchSize 명씩 나누어 집계합니다.
+func (u LinkBookUsecase) GetLinkBookNameDrift(userIds []string) (*LinkBookNameDriftRes, error) {
+	res := &LinkBookNameDriftRes{Drifts: []LinkBookNameDrift{}}
+
+	err := u.forEachUserBatch(userIds, func(batch []string) error {
+		drifts, err := u.linkModel.GetLinkBookNameDrifts(batch)
+		if err != nil {
+			return err
+		}
+
+		for _, drift := range drifts {
+			res.TotalDriftedLinks += drift.DriftedLinks
+		}
+		res.Drifts = append(res.Drifts, drifts...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(res.Drifts, func(i, j int) bool {
+		return res.Drifts[i].DriftedLinks > res.Drifts[j].DriftedLinks
+	})
+
+	return res, nil
+}
+
+// RepairLinkBookNameDrift 는 어긋난 링크북 이름을 현재 제목으로 복구합니다.
+// 링크북이 삭제되어 제목을 알 수 없는 링크는 건드리지 않습니다. userIds 가 없으면 전체 사용자를 나누어 복구합니다.
+func (u LinkBookUsecase) RepairLinkBookNameDrift(userIds []string) (*LinkBookNameRepairRes, error) {
+	var repaired int64
+
+	err := u.forEachUserBatch(userIds, func(batch []string) error {
+		drifts, err := u.linkModel.GetLinkBookNameDrifts(batch)
+		if err != nil {
+			return err
+		}
+
+		for _, drift := range drifts {
+			if drift.Title == nil {
+				continue
+			}
+
+			count, err := u.linkModel.SyncLinkBookName(drift.LinkBookId, *drift.Title)
+			if err != nil {
+				return err
+			}
+			repaired += count
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LinkBookNameRepairRes{RepairedLinks: repaired}, nil
+}
+
+// forEachUserBatch 는 userIds 를 (없으면 전체 사용자를) linkBookNameDriftBatchSize 명씩 나누어 fn 을 실행합니다.
+func (u LinkBookUsecase) forEachUserBatch(userIds []string, fn func(batch []string) error) error {
+	if len(userIds) > 0 {
+		for start := 0; start < len(userIds); start += linkBookNameDriftBatchSize {
+			end := start + linkBookNameDriftBatchSize
+			if end > len(userIds) {
+				end = len(userIds)
+			}
+			if err := fn(userIds[start:end]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	after := ""
+	for {
+		batch, err := (&user.UserModel{}).FindUserIds(after, linkBookNameDriftBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := fn(batch); err != nil {
+			return err
+		}
+		after = batch[len(batch)-1]
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
 	"joosum-backend/app/user"
+	"joosum-backend/pkg/util"
 )
 
 type NotificationHandler struct {
@@ -16,17 +17,51 @@ type NotificationHandler struct {
 // Notifications
 // @Tags 알림
 // @Summary 알림 목록 조회
-// @Param page query int false "페이지"
+// @Description 최근 30일 동안의 알림을 최신순으로 10개씩 반환합니다.
+// @Param page query int false "페이지 (기본 1)"
+// @Param type query string false "알림 종류 (unread, unclassified, reminder). 비우면 전체"
 // @Success 200 {object} notificationResDocs
+// @Failure 400 {object} util.APIError
 // @Security ApiKeyAuth
 // @Router /notifications [get]
 func (h NotificationHandler) Notifications(c *gin.Context) {
 	userId := user.GetUserId(c)
-	page, err := ParseInt(c.Query("page"), 10, 64)
-	result, err := h.notificationUsecase.Notifications(userId, page)
+
+	page := int64(1)
+	if value := c.Query("page"); value != "" {
+		var err error
+		page, err = ParseInt(value, 10, 64)
+		if err != nil || page < 1 {
+			// 400 Bad Request
+			util.SendError(c, http.StatusBadRequest, util.CodeInvalidParameter)
+			return
+		}
+	}
+
+	result, err := h.notificationUsecase.Notifications(userId, c.Query("type"), page)
 	if err != nil {
-		// 500 Internal Server Error
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.sendError(c, err)
+		return
+	}
+
+	// 200 OK
+	c.JSON(http.StatusOK, result)
+}
+
+// UnreadCount
+// @Tags 알림
+// @Summary 읽지 않은 알림 수 조회
+// @Param type query string false "알림 종류 (unread, unclassified, reminder). 비우면 전체"
+// @Success 200 {object} NotificationCountRes
+// @Failure 400 {object} util.APIError
+// @Security ApiKeyAuth
+// @Router /notifications/unread-count [get]
+func (h NotificationHandler) UnreadCount(c *gin.Context) {
+	userId := user.GetUserId(c)
+
+	result, err := h.notificationUsecase.UnreadCount(userId, c.Query("type"))
+	if err != nil {
+		h.sendError(c, err)
 		return
 	}
 
@@ -39,20 +74,94 @@ func (h NotificationHandler) Notifications(c *gin.Context) {
 // @Summary 알림 읽음처리
 // @Param notificationId path string true "알림 ID"
 // @Success 204 {object} nil
+// @Failure 404 {object} util.APIError
 // @Security ApiKeyAuth
 // @Router /notifications/{notificationId} [put]
 func (h NotificationHandler) ReadNotification(c *gin.Context) {
+	userId := user.GetUserId(c)
 	notificationId := c.Param("notificationId")
 
-	err := h.notificationUsecase.ReadNotification(notificationId)
+	err := h.notificationUsecase.ReadNotification(userId, notificationId)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.sendError(c, err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+// ReadAllNotifications
+// @Tags 알림
+// @Summary 알림 모두 읽음처리
+// @Param type query string false "알림 종류 (unread, unclassified, reminder). 비우면 전체"
+// @Success 200 {object} NotificationUpdateRes
+// @Failure 400 {object} util.APIError
+// @Security ApiKeyAuth
+// @Router /notifications/read-all [put]
+func (h NotificationHandler) ReadAllNotifications(c *gin.Context) {
+	userId := user.GetUserId(c)
+
+	result, err := h.notificationUsecase.ReadAllNotifications(userId, c.Query("type"))
+	if err != nil {
+		h.sendError(c, err)
+		return
+	}
+
+	// 200 OK
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteNotification
+// @Tags 알림
+// @Summary 알림 삭제
+// @Param notificationId path string true "알림 ID"
+// @Success 204 {object} nil
+// @Failure 404 {object} util.APIError
+// @Security ApiKeyAuth
+// @Router /notifications/{notificationId} [delete]
+func (h NotificationHandler) DeleteNotification(c *gin.Context) {
+	userId := user.GetUserId(c)
+
+	err := h.notificationUsecase.DeleteNotification(userId, c.Param("notificationId"))
+	if err != nil {
+		h.sendError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteNotifications
+// @Tags 알림
+// @Summary 알림 모두 삭제
+// @Param type query string false "알림 종류 (unread, unclassified, reminder). 비우면 전체"
+// @Success 200 {object} NotificationDeleteRes
+// @Failure 400 {object} util.APIError
+// @Security ApiKeyAuth
+// @Router /notifications [delete]
+func (h NotificationHandler) DeleteNotifications(c *gin.Context) {
+	userId := user.GetUserId(c)
+
+	result, err := h.notificationUsecase.DeleteNotifications(userId, c.Query("type"))
+	if err != nil {
+		h.sendError(c, err)
+		return
+	}
+
+	// 200 OK
+	c.JSON(http.StatusOK, result)
+}
+
+func (h NotificationHandler) sendError(c *gin.Context, err error) {
+	switch err {
+	case ErrUnknownNotificationType:
+		// 400 Bad Request
+		util.SendError(c, http.StatusBadRequest, util.CodeInvalidParameter)
+	case mongo.ErrNoDocuments:
+		// 404 Not Found
+		util.SendError(c, http.StatusNotFound, util.CodeNotificationNotFound)
+	default:
+		// 500 Internal Server Error
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -23,6 +23,8 @@ type Notification struct {
 	Type           string    `json:"type" bson:"type" example:"unread"`
 	CreatedAt      time.Time `json:"createdAt" bson:"created_at"`
 	UserId         string    `json:"userId" bson:"user_id" example:"User-590e39b3-7661-4387-8501-85aaf87d133c"`
+	// 리마인더 알림의 링크 아이디. 다른 알림에는 없음
+	LinkId string `json:"linkId,omitempty" bson:"link_id,omitempty" example:"Link-1b2c3d4e-5f60-4718-9a2b-3c4d5e6f7a8b"`
 }
 
 type NotificationRes struct {
@@ -35,27 +37,56 @@ type notificationResDocs struct {
 	Page          *db.PaginationData `json:"page" bson:"page"`
 }
 
-func (NotificationModel) Notifications(userId string, page int64) (*NotificationRes, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// 알림 목록, 개수 응답
+type NotificationCountRes struct {
+	Count int64 `json:"count" example:"3"`
+}
+
+type NotificationUpdateRes struct {
+	UpdatedCount int64 `json:"updatedCount" example:"3"`
+}
 
-	thirtyDaysAgo := time.Now().AddDate(0, 0, -30)
+type NotificationDeleteRes struct {
+	DeletedCount int64 `json:"deletedCount" example:"3"`
+}
 
-	filter := bson.M{
-		"user_id":    userId,
-		"created_at": bson.M{"$gte": thirtyDaysAgo},
+// notificationFilter 는 사용자의 알림 조건입니다. notificationType 이 비어 있으면 모든 종류입니다.
+// 30일이 지난 알림은 TTL 인덱스(db.NotificationRetentionDays)로 지워지므로 날짜 조건을 두지 않습니다.
+func notificationFilter(userId, notificationType string) bson.M {
+	filter := bson.M{"user_id": userId}
+	if notificationType != "" {
+		filter["type"] = notificationType
 	}
+	return filter
+}
+
+func (NotificationModel) Notifications(userId string, notificationType string, page int64) (*NotificationRes, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	var notifications []Notification
+	filter := notificationFilter(userId, notificationType)
+
+	notifications := []Notification{}
 	paginatedData, err := New(db.NotificationCollection).Context(ctx).Limit(10).Page(page).Sort("created_at", -1).Select(bson.D{}).Filter(filter).Decode(&notifications).Find()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	return &NotificationRes{Notifications: notifications, Page: &paginatedData.Pagination}, nil
 }
 
-func (NotificationModel) UpdateIsRead(notificationId string) error {
+func (NotificationModel) CountUnread(userId string, notificationType string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := notificationFilter(userId, notificationType)
+	filter["is_read"] = false
+
+	return db.NotificationCollection.CountDocuments(ctx, filter)
+}
+
+// UpdateIsRead 는 사용자의 알림 하나를 읽음 처리합니다. 없거나 다른 사용자의 알림이면 mongo.ErrNoDocuments 를 반환합니다.
+func (NotificationModel) UpdateIsRead(userId string, notificationId string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -65,9 +96,47 @@ func (NotificationModel) UpdateIsRead(notificationId string) error {
 		},
 	}
 
-	err := db.NotificationCollection.FindOneAndUpdate(ctx, bson.M{"_id": notificationId}, update).Decode(&mongo.SingleResult{})
+	err := db.NotificationCollection.FindOneAndUpdate(ctx, bson.M{"_id": notificationId, "user_id": userId}, update).Decode(&mongo.SingleResult{})
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// UpdateAllIsRead 는 사용자의 읽지 않은 알림을 모두 읽음 처리하고, 바꾼 수를 반환합니다.
+func (NotificationModel) UpdateAllIsRead(userId string, notificationType string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := notificationFilter(userId, notificationType)
+	filter["is_read"] = false
+	update := bson.M{"$set": bson.M{"is_read": true}}
+
+	result, err := db.NotificationCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (NotificationModel) DeleteNotification(userId string, notificationId string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := db.NotificationCollection.DeleteOne(ctx, bson.M{"_id": notificationId, "user_id": userId})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+func (NotificationModel) DeleteNotifications(userId string, notificationType string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := db.NotificationCollection.DeleteMany(ctx, notificationFilter(userId, notificationType))
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -1,21 +1,96 @@
 package notif
 
+import (
+	"errors"
+
+	"joosum-backend/app/setting"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrUnknownNotificationType = errors.New("unknown notification type")
+
 type NotificationUsecase struct {
 	notificationModel NotificationModel
 }
 
-func (u NotificationUsecase) Notifications(userId string, page int64) (*NotificationRes, error) {
-	result, err := u.notificationModel.Notifications(userId, page)
+// validateType 은 알림 종류 필터를 확인합니다. 비어 있으면 모든 종류입니다.
+func validateType(notificationType string) error {
+	if notificationType == "" {
+		return nil
+	}
+	for _, t := range setting.NotificationTypes {
+		if t == notificationType {
+			return nil
+		}
+	}
+	return ErrUnknownNotificationType
+}
+
+func (u NotificationUsecase) Notifications(userId string, notificationType string, page int64) (*NotificationRes, error) {
+	if err := validateType(notificationType); err != nil {
+		return nil, err
+	}
+
+	result, err := u.notificationModel.Notifications(userId, notificationType, page)
 	if err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
-func (u NotificationUsecase) ReadNotification(notificationId string) error {
-	err := u.notificationModel.UpdateIsRead(notificationId)
+func (u NotificationUsecase) UnreadCount(userId string, notificationType string) (*NotificationCountRes, error) {
+	if err := validateType(notificationType); err != nil {
+		return nil, err
+	}
+
+	count, err := u.notificationModel.CountUnread(userId, notificationType)
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationCountRes{Count: count}, nil
+}
+
+func (u NotificationUsecase) ReadNotification(userId string, notificationId string) error {
+	err := u.notificationModel.UpdateIsRead(userId, notificationId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u NotificationUsecase) ReadAllNotifications(userId string, notificationType string) (*NotificationUpdateRes, error) {
+	if err := validateType(notificationType); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.notificationModel.UpdateAllIsRead(userId, notificationType)
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationUpdateRes{UpdatedCount: updated}, nil
+}
+
+// DeleteNotification 은 사용자의 알림 하나를 지웁니다. 없으면 mongo.ErrNoDocuments 를 반환합니다.
+func (u NotificationUsecase) DeleteNotification(userId string, notificationId string) error {
+	deleted, err := u.notificationModel.DeleteNotification(userId, notificationId)
 	if err != nil {
 		return err
 	}
+	if deleted == 0 {
+		return mongo.ErrNoDocuments
+	}
 	return nil
 }
+
+func (u NotificationUsecase) DeleteNotifications(userId string, notificationType string) (*NotificationDeleteRes, error) {
+	if err := validateType(notificationType); err != nil {
+		return nil, err
+	}
+
+	deleted, err := u.notificationModel.DeleteNotifications(userId, notificationType)
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationDeleteRes{DeletedCount: deleted}, nil
+}
@@ -0,0 +1,129 @@
+package setting
+
+import (
+	"context"
+	"time"
+
+	"joosum-backend/pkg/db"
+	"joosum-backend/pkg/util"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 디바이스 플랫폼
+const (
+	PlatformIOS     = "ios"
+	PlatformAndroid = "android"
+	PlatformWeb     = "web"
+	// 플랫폼을 보내지 않은 예전 앱, 또는 notificationAgrees.device_id 에만 있던 토큰
+	PlatformUnknown = "unknown"
+)
+
+// Platforms 는 플랫폼별 푸시 제공자 설정(pushProviders.<플랫폼>)을 읽을 플랫폼 목록입니다.
+var Platforms = []string{PlatformIOS, PlatformAndroid, PlatformWeb, PlatformUnknown}
+
+// 이 기간 동안 앱에서 디바이스를 등록(POST /settings/device)하지 않으면 푸시를 보내지 않습니다.
+const DeviceActiveDays = 90
+
+type DeviceModel struct{}
+
+// Device 는 사용자의 푸시 수신 디바이스입니다. (devices 컬렉션)
+// 한 사용자가 여러 디바이스를 가질 수 있고, 같은 토큰은 마지막으로 등록한 사용자 하나에만 속합니다.
+type Device struct {
+	Id         string    `bson:"_id" json:"id" example:"Device-0e2f6c1a-7a43-4a4b-9f0b-6f1d2b0c9e11"`
+	UserId     string    `bson:"user_id" json:"userId" example:"User-dea95e0a-6d06-4d9f-bd2e-094bcedcc792"`
+	Token      string    `bson:"token" json:"token" example:"fcm-registration-token"`
+	Platform   string    `bson:"platform" json:"platform" example:"ios"`
+	AppVersion string    `bson:"app_version" json:"appVersion" example:"1.4.2"`
+	LastSeenAt time.Time `bson:"last_seen_at" json:"lastSeenAt"`
+	CreatedAt  time.Time `bson:"created_at" json:"createdAt"`
+}
+
+// UpsertDevice 는 토큰으로 디바이스를 등록하거나 플랫폼, 앱 버전, 마지막 접속 시각을 갱신합니다.
+// 다른 사용자가 등록했던 토큰이면 (같은 기기에서 다른 계정으로 로그인) 현재 사용자로 옮깁니다.
+func (DeviceModel) UpsertDevice(userId, token, platform, appVersion string) (*Device, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"token": token}
+	update := bson.M{
+		"$set": bson.M{
+			"user_id":      userId,
+			"platform":     platform,
+			"app_version":  appVersion,
+			"last_seen_at": now,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        util.CreateId("Device"),
+			"created_at": now,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var device Device
+	err := db.DeviceCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&device)
+	if err != nil {
+		return nil, err
+	}
+
+	return &device, nil
+}
+
+// GetDevices 는 사용자의 디바이스를 최근 접속 순으로 반환합니다.
+func (DeviceModel) GetDevices(userId string) ([]Device, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}})
+
+	cur, err := db.DeviceCollection.Find(ctx, bson.M{"user_id": userId}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := []Device{}
+	if err = cur.All(ctx, &devices); err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+// GetActiveDevices 는 since 이후 접속한 사용자의 디바이스를 반환합니다.
+func (DeviceModel) GetActiveDevices(userId string, since time.Time) ([]Device, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userId, "last_seen_at": bson.M{"$gte": since}}
+
+	cur, err := db.DeviceCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := []Device{}
+	if err = cur.All(ctx, &devices); err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+// DeleteDevice 는 사용자의 디바이스 하나를 지웁니다.
+func (DeviceModel) DeleteDevice(userId, id string) (*mongo.DeleteResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return db.DeviceCollection.DeleteOne(ctx, bson.M{"_id": id, "user_id": userId})
+}
+
+// DeleteDevices 는 사용자의 모든 디바이스를 지웁니다.
+func (DeviceModel) DeleteDevices(userId string) (*mongo.DeleteResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return db.DeviceCollection.DeleteMany(ctx, bson.M{"user_id": userId})
+}
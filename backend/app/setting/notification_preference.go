@@ -0,0 +1,227 @@
+package setting
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"joosum-backend/pkg/i18n"
+)
+
+// 알림 종류. 새 알림을 추가하면 NotificationTypes 에도 추가해 사용자가 설정할 수 있게 합니다.
+const (
+	NotificationTypeUnread       = "unread"
+	NotificationTypeUnclassified = "unclassified"
+	// 링크 리마인더. 사용자가 정한 시각에 보내므로 빈도, 알림 시각, 조용한 시간은 적용하지 않고 푸시 수신 동의만 봅니다.
+	NotificationTypeReminder = "reminder"
+)
+
+var NotificationTypes = []string{NotificationTypeUnread, NotificationTypeUnclassified, NotificationTypeReminder}
+
+// 알림 빈도
+const (
+	FrequencyDaily  = "daily"
+	FrequencyWeekly = "weekly"
+	// 알림을 만들지 않음 (알림함에도 저장하지 않음)
+	FrequencyOff = "off"
+)
+
+// 설정하지 않은 사용자의 기본값
+const (
+	DefaultTimezone   = "Asia/Seoul"
+	DefaultNotifyHour = 19
+	DefaultFrequency  = FrequencyWeekly
+)
+
+var (
+	ErrInvalidTimezone         = errors.New("invalid timezone")
+	ErrInvalidLocale           = errors.New("invalid locale")
+	ErrInvalidQuietHours       = errors.New("invalid quiet hours")
+	ErrInvalidNotifyHour       = errors.New("invalid notify hour")
+	ErrInvalidFrequency        = errors.New("invalid frequency")
+	ErrUnknownNotificationType = errors.New("unknown notification type")
+)
+
+// NotificationPreference 는 알림 종류 하나의 설정입니다.
+type NotificationPreference struct {
+	// 푸시 수신 동의. false 여도 빈도가 off 가 아니면 알림함에는 저장합니다.
+	Push bool `bson:"push" json:"push"`
+	// daily, weekly, off
+	Frequency string `bson:"frequency" json:"frequency" example:"weekly"`
+}
+
+// QuietHours 는 알림을 보내지 않는 시간대입니다. (사용자 시간대 기준, HH:MM)
+// Start 가 End 보다 늦으면 자정을 넘기는 구간입니다. (22:00 ~ 08:00)
+type QuietHours struct {
+	Start string `bson:"start" json:"start" example:"22:00"`
+	End   string `bson:"end" json:"end" example:"08:00"`
+}
+
+// NotificationSent 는 알림 종류 하나를 마지막으로 만든 기록입니다.
+// 알림함의 알림은 사용자가 지우거나 30일 후 삭제되므로 빈도(IsDue)는 알림함이 아니라 이 기록으로 셉니다.
+type NotificationSent struct {
+	At time.Time `bson:"at"`
+	// 알림을 만든 알림 작업 실행의 키. 같은 실행을 이어서 할 때 이미 처리한 사용자를 건너뜁니다.
+	RunKey string `bson:"run_key"`
+}
+
+// Location 은 사용자의 시간대입니다. 설정하지 않았거나 잘못된 값이면 Asia/Seoul 입니다.
+func (a NotificationAgree) Location() *time.Location {
+	name := a.Timezone
+	if name == "" {
+		name = DefaultTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc, _ = time.LoadLocation(DefaultTimezone)
+	}
+	if loc == nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Preference 는 알림 종류의 설정입니다. 설정하지 않은 종류는 예전 동의 여부(is_read_agree, is_classify_agree)와 주 1회로 정합니다.
+func (a NotificationAgree) Preference(notificationType string) NotificationPreference {
+	if pref, ok := a.Preferences[notificationType]; ok {
+		if pref.Frequency == "" {
+			pref.Frequency = DefaultFrequency
+		}
+		return pref
+	}
+
+	pref := NotificationPreference{Frequency: DefaultFrequency}
+	switch notificationType {
+	case NotificationTypeUnread:
+		pref.Push = a.IsReadAgree
+	case NotificationTypeUnclassified:
+		pref.Push = a.IsClassifyAgree
+	case NotificationTypeReminder:
+		// 사용자가 직접 예약한 알림이므로 기본으로 보냄
+		pref.Push = true
+	}
+	return pref
+}
+
+// IsDeliveryTime 은 now 가 사용자에게 알림을 보낼 수 있는 시각인지 확인합니다.
+// 사용자 시간대로 알림 시각(notifyHour) 이후이고 조용한 시간이 아니어야 합니다.
+func (a NotificationAgree) IsDeliveryTime(now time.Time) bool {
+	local := now.In(a.Location())
+
+	notifyHour := DefaultNotifyHour
+	if a.NotifyHour != nil {
+		notifyHour = *a.NotifyHour
+	}
+	if local.Hour() < notifyHour {
+		return false
+	}
+
+	if a.QuietHours != nil {
+		start, startErr := parseClock(a.QuietHours.Start)
+		end, endErr := parseClock(a.QuietHours.End)
+		if startErr == nil && endErr == nil && inQuietHours(local.Hour()*60+local.Minute(), start, end) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsDue 는 마지막으로 알림을 만든 시각(lastAt, 없으면 zero)과 빈도로 이번에 알림을 만들지 확인합니다.
+// 날짜는 사용자 시간대로 셉니다. 매일이면 오늘 받은 적이 없을 때, 매주면 마지막 알림 후 7일이 지났을 때 보냅니다.
+func (a NotificationAgree) IsDue(notificationType string, lastAt, now time.Time) bool {
+	pref := a.Preference(notificationType)
+	if pref.Frequency == FrequencyOff {
+		return false
+	}
+	if lastAt.IsZero() {
+		return true
+	}
+
+	loc := a.Location()
+	days := localDate(now, loc).Sub(localDate(lastAt, loc)).Hours() / 24
+
+	switch pref.Frequency {
+	case FrequencyDaily:
+		return days >= 1
+	default:
+		return days >= 7
+	}
+}
+
+// ValidateNotificationSettings 는 알림 설정 수정 요청의 값을 확인합니다.
+func ValidateNotificationSettings(req PushNotificationReq) error {
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "" {
+			return ErrInvalidTimezone
+		}
+	}
+	if req.Locale != nil && !i18n.IsSupported(*req.Locale) {
+		return ErrInvalidLocale
+	}
+	if req.QuietHours != nil && (req.QuietHours.Start != "" || req.QuietHours.End != "") {
+		if _, err := parseClock(req.QuietHours.Start); err != nil {
+			return ErrInvalidQuietHours
+		}
+		if _, err := parseClock(req.QuietHours.End); err != nil {
+			return ErrInvalidQuietHours
+		}
+	}
+	if req.NotifyHour != nil && (*req.NotifyHour < 0 || *req.NotifyHour > 23) {
+		return ErrInvalidNotifyHour
+	}
+	for notificationType, pref := range req.Preferences {
+		if !isNotificationType(notificationType) {
+			return fmt.Errorf("%w: %s", ErrUnknownNotificationType, notificationType)
+		}
+		switch pref.Frequency {
+		case "", FrequencyDaily, FrequencyWeekly, FrequencyOff:
+		default:
+			return fmt.Errorf("%w: %s", ErrInvalidFrequency, pref.Frequency)
+		}
+	}
+	return nil
+}
+
+func isNotificationType(notificationType string) bool {
+	for _, t := range NotificationTypes {
+		if t == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock 은 HH:MM 을 자정부터의 분으로 바꿉니다.
+func parseClock(value string) (int, error) {
+	hour, minute, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, ErrInvalidQuietHours
+	}
+	h, err := strconv.Atoi(hour)
+	if err != nil || h < 0 || h > 23 {
+		return 0, ErrInvalidQuietHours
+	}
+	m, err := strconv.Atoi(minute)
+	if err != nil || m < 0 || m > 59 || len(minute) != 2 {
+		return 0, ErrInvalidQuietHours
+	}
+	return h*60 + m, nil
+}
+
+func inQuietHours(minutes, start, end int) bool {
+	if start == end {
+		return false
+	}
+	if start < end {
+		return minutes >= start && minutes < end
+	}
+	return minutes >= start || minutes < end
+}
+
+func localDate(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -1,10 +1,13 @@
 package setting
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
 	"joosum-backend/app/user"
+	"joosum-backend/pkg/util"
 )
 
 type SettingHandler struct {
@@ -13,9 +16,11 @@ type SettingHandler struct {
 
 // SaveDeviceId
 // @Tags 설정
-// @Summary 푸시 디바이스 ID 저장
+// @Summary 푸시 디바이스 등록
+// @Description 디바이스 토큰을 등록하거나 마지막 접속 시각을 갱신합니다. 한 사용자가 여러 디바이스를 등록할 수 있으며, 앱 실행 시마다 호출합니다.
+// @Description 90일 동안 등록(갱신)하지 않은 디바이스에는 푸시를 보내지 않습니다.
 // @Param request body DeviceReq true "request"
-// @Success 200 {object} db.UpdateResult
+// @Success 200 {object} Device
 // @Security ApiKeyAuth
 // @Router /settings/device [post]
 func (h SettingHandler) SaveDeviceId(c *gin.Context) {
@@ -28,7 +33,27 @@ func (h SettingHandler) SaveDeviceId(c *gin.Context) {
 		return
 	}
 
-	result, err := h.settingUsecase.SaveDeviceId(req.DeviceId, userId)
+	result, err := h.settingUsecase.SaveDevice(req, userId)
+	if err != nil {
+		// 500 Internal Server Error
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 200 OK
+	c.JSON(http.StatusOK, result)
+}
+
+// GetDevices
+// @Tags 설정
+// @Summary 푸시 디바이스 목록 조회
+// @Success 200 {array} Device
+// @Security ApiKeyAuth
+// @Router /settings/devices [get]
+func (h SettingHandler) GetDevices(c *gin.Context) {
+	userId := user.GetUserId(c)
+
+	result, err := h.settingUsecase.GetDevices(userId)
 	if err != nil {
 		// 500 Internal Server Error
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -39,9 +64,37 @@ func (h SettingHandler) SaveDeviceId(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// DeleteDevice
+// @Tags 설정
+// @Summary 푸시 디바이스 삭제
+// @Description 다른 기기를 목록에서 지워 더 이상 푸시가 가지 않게 합니다.
+// @Param id path string true "디바이스 ID (GET /settings/devices 의 id)"
+// @Success 204
+// @Failure 404 {object} util.APIError
+// @Security ApiKeyAuth
+// @Router /settings/devices/{id} [delete]
+func (h SettingHandler) DeleteDevice(c *gin.Context) {
+	userId := user.GetUserId(c)
+
+	err := h.settingUsecase.DeleteDevice(userId, c.Param("id"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			util.SendError(c, http.StatusNotFound, util.CodeDeviceNotFound)
+			return
+		}
+		// 500 Internal Server Error
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 204 No Content
+	c.Status(http.StatusNoContent)
+}
+
 // GetNotificationAgree
 // @Tags 설정
-// @Summary 푸시알림 여부 조회
+// @Summary 푸시알림 설정 조회
+// @Description 알림 종류별 푸시 동의와 빈도(daily, weekly, off), 시간대, 알림 문구 언어, 알림 시각, 조용한 시간을 반환합니다. 설정하지 않은 값은 기본값으로 채웁니다.
 // @Success 200 {object} NotificationAgree
 // @Security ApiKeyAuth
 // @Router /settings/notification [get]
@@ -60,9 +113,11 @@ func (h SettingHandler) GetNotificationAgree(c *gin.Context) {
 
 // UpdatePushNotification
 // @Tags 설정
-// @Summary 푸시알림 여부 수정
+// @Summary 푸시알림 설정 수정
+// @Description 보낸 값만 바꿉니다. 알림 작업은 사용자 시간대로 알림 시각 이후, 조용한 시간이 아닐 때 빈도에 맞춰 알림을 보냅니다.
 // @Param request body PushNotificationReq true "request"
 // @Success 200 {object} db.UpdateResult
+// @Failure 400 {object} util.APIError
 // @Security ApiKeyAuth
 // @Router /settings/notification [put]
 func (h SettingHandler) UpdatePushNotification(c *gin.Context) {
@@ -77,6 +132,11 @@ func (h SettingHandler) UpdatePushNotification(c *gin.Context) {
 
 	result, err := h.settingUsecase.UpdatePushNotification(req, userId)
 	if err != nil {
+		if isNotificationSettingError(err) {
+			// 400 Bad Request
+			util.SendError(c, http.StatusBadRequest, util.CodeInvalidNotificationSetting)
+			return
+		}
 		// 500 Internal Server Error
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -85,3 +145,12 @@ func (h SettingHandler) UpdatePushNotification(c *gin.Context) {
 	// 200 OK
 	c.JSON(http.StatusOK, result)
 }
+
+func isNotificationSettingError(err error) bool {
+	for _, target := range []error{ErrInvalidTimezone, ErrInvalidLocale, ErrInvalidQuietHours, ErrInvalidNotifyHour, ErrInvalidFrequency, ErrUnknownNotificationType} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
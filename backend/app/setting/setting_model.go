@@ -15,27 +15,66 @@ type SettingModel struct {
 
 type NotificationAgree struct {
 	NotificationAgreeId *string `bson:"_id" json:"notificationAgreeId" example:"652bf9508de1187ff1e16e24"`
-	DeviceId            *string `bson:"device_id" json:"deviceId"`
-	IsReadAgree         bool    `bson:"is_read_agree" json:"isReadAgree"`
-	IsClassifyAgree     bool    `bson:"is_classify_agree" json:"isClassifyAgree"`
-	UserId              string  `bson:"user_id" json:"userId" example:"User-dea95e0a-6d06-4d9f-bd2e-094bcedcc792"`
+	// 디바이스 목록(devices) 이전에 저장하던 단일 토큰. 새로 등록하면 비워지며, 알림 작업은 디바이스 목록에 없는 경우에만 사용합니다.
+	DeviceId        *string `bson:"device_id" json:"deviceId"`
+	IsReadAgree     bool    `bson:"is_read_agree" json:"isReadAgree"`
+	IsClassifyAgree bool    `bson:"is_classify_agree" json:"isClassifyAgree"`
+	UserId          string  `bson:"user_id" json:"userId" example:"User-dea95e0a-6d06-4d9f-bd2e-094bcedcc792"`
+	// IANA 시간대. 비어 있으면 Asia/Seoul
+	Timezone string `bson:"timezone,omitempty" json:"timezone" example:"Asia/Seoul"`
+	// 알림 문구 언어 (ko, en). 비어 있으면 ko
+	Locale string `bson:"locale,omitempty" json:"locale" example:"ko"`
+	// 이 시각(사용자 시간대, 0~23시) 이후에 알림을 보냅니다. 비어 있으면 19시
+	NotifyHour *int        `bson:"notify_hour,omitempty" json:"notifyHour" example:"19"`
+	QuietHours *QuietHours `bson:"quiet_hours,omitempty" json:"quietHours"`
+	// 알림 종류(unread, unclassified)별 설정. 없는 종류는 is_read_agree, is_classify_agree 와 주 1회로 봅니다.
+	Preferences map[string]NotificationPreference `bson:"preferences,omitempty" json:"preferences"`
+	// 알림 종류별로 마지막으로 알림을 만든 기록. 알림 작업이 빈도를 셀 때 씁니다.
+	LastSent map[string]NotificationSent `bson:"last_sent,omitempty" json:"-"`
 }
 
 type DeviceReq struct {
-	DeviceId string `json:"deviceId"`
+	// FCM 등록 토큰
+	DeviceId string `json:"deviceId" binding:"required"`
+	// ios, android, web. 비우면 unknown
+	Platform   string `json:"platform" example:"ios"`
+	AppVersion string `json:"appVersion" example:"1.4.2"`
 }
 
+// PushNotificationReq 는 알림 설정 수정 요청입니다. 보내지 않은 값은 바꾸지 않습니다.
 type PushNotificationReq struct {
-	IsReadAgree     bool `json:"isReadAgree"`
-	IsClassifyAgree bool `json:"isClassifyAgree"`
+	// preferences.unread.push 와 같습니다. (예전 앱 호환)
+	IsReadAgree *bool `json:"isReadAgree"`
+	// preferences.unclassified.push 와 같습니다. (예전 앱 호환)
+	IsClassifyAgree *bool   `json:"isClassifyAgree"`
+	Timezone        *string `json:"timezone" example:"Asia/Seoul"`
+	Locale          *string `json:"locale" example:"en"`
+	NotifyHour      *int    `json:"notifyHour" example:"19"`
+	// start, end 를 모두 비우면 조용한 시간을 끕니다.
+	QuietHours  *QuietHours                          `json:"quietHours"`
+	Preferences map[string]NotificationPreferenceReq `json:"preferences"`
 }
 
-func (SettingModel) SaveDeviceId(deviceId, userId string) (*mongo.UpdateResult, error) {
+type NotificationPreferenceReq struct {
+	Push *bool `json:"push"`
+	// daily, weekly, off. 비우면 바꾸지 않음
+	Frequency string `json:"frequency" example:"daily"`
+}
+
+// CreateDefaultNotificationAgree 는 알림 동의 정보가 없으면 모두 동의(Y)로 만듭니다.
+// 예전 방식으로 저장된 device_id 는 디바이스 목록(devices)으로 옮긴 뒤 호출하므로 비웁니다.
+func (SettingModel) CreateDefaultNotificationAgree(userId string) (*mongo.UpdateResult, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	filter := bson.D{{"user_id", userId}}
-	update := bson.D{{"$set", bson.D{{"device_id", deviceId}}}}
+	filter := bson.M{"user_id": userId}
+	update := bson.M{
+		"$set": bson.M{"device_id": nil},
+		"$setOnInsert": bson.M{
+			"is_read_agree":     true,
+			"is_classify_agree": true,
+		},
+	}
 	opts := options.Update().SetUpsert(true)
 
 	result, err := db.NotificationAgreeCollection.UpdateOne(ctx, filter, update, opts)
@@ -59,14 +98,20 @@ func (SettingModel) GetNotificationAgree(userId string) (*NotificationAgree, err
 	return &agree, nil
 }
 
-func (SettingModel) UpdatePushNotification(req PushNotificationReq, userId string) (*mongo.UpdateResult, error) {
+// UpdatePushNotification 은 알림 동의 여부와 시간대, 알림 시각, 조용한 시간, 종류별 설정을 저장합니다.
+func (SettingModel) UpdatePushNotification(agree NotificationAgree, userId string) (*mongo.UpdateResult, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	filter := bson.D{{"user_id", userId}}
 	update := bson.D{{"$set", bson.D{
-		{"is_read_agree", req.IsReadAgree},
-		{"is_classify_agree", req.IsClassifyAgree},
+		{"is_read_agree", agree.IsReadAgree},
+		{"is_classify_agree", agree.IsClassifyAgree},
+		{"timezone", agree.Timezone},
+		{"locale", agree.Locale},
+		{"notify_hour", agree.NotifyHour},
+		{"quiet_hours", agree.QuietHours},
+		{"preferences", agree.Preferences},
 	}}}
 	opts := options.Update().SetUpsert(true)
 
@@ -77,6 +122,18 @@ func (SettingModel) UpdatePushNotification(req PushNotificationReq, userId strin
 	return result, nil
 }
 
+// UpdateLastSent 는 notificationType 알림을 마지막으로 만든 기록(last_sent.<종류>)을 저장합니다.
+func (SettingModel) UpdateLastSent(userId, notificationType string, sent NotificationSent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userId}
+	update := bson.M{"$set": bson.M{"last_sent." + notificationType: sent}}
+
+	_, err := db.NotificationAgreeCollection.UpdateOne(ctx, filter, update)
+	return err
+}
+
 func (SettingModel) DeleteDivceId(userId string) (*mongo.UpdateResult, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -94,3 +151,24 @@ func (SettingModel) DeleteDivceId(userId string) (*mongo.UpdateResult, error) {
 	return result, nil
 
 }
+
+// DeleteDeviceToken 은 사용자의 디바이스 토큰 하나를 디바이스 목록과 예전 device_id 에서 지웁니다.
+// (디바이스별 로그아웃, 푸시를 받을 수 없는 토큰 정리) 예전 device_id 는 토큰이 같을 때만 비웁니다.
+func (SettingModel) DeleteDeviceToken(userId, deviceId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	deleted, err := db.DeviceCollection.DeleteOne(ctx, bson.M{"user_id": userId, "token": deviceId})
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{"user_id": userId, "device_id": deviceId}
+	update := bson.M{"$set": bson.M{"device_id": nil}}
+
+	result, err := db.NotificationAgreeCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return deleted.DeletedCount > 0 || result.ModifiedCount > 0, nil
+}
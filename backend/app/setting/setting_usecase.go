@@ -1,31 +1,97 @@
 package setting
 
 import (
+	"strings"
+
+	"joosum-backend/pkg/i18n"
+
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type SettingUsecase struct {
 	settingModel SettingModel
+	deviceModel  DeviceModel
 }
 
-func (u SettingUsecase) SaveDeviceId(deviceId, userId string) (*mongo.UpdateResult, error) {
-	result, err := u.settingModel.SaveDeviceId(deviceId, userId)
+// SaveDevice 는 디바이스를 등록하거나 마지막 접속 시각을 갱신합니다. 앱 실행 시마다 호출됩니다.
+func (u SettingUsecase) SaveDevice(req DeviceReq, userId string) (*Device, error) {
+	platform := strings.ToLower(strings.TrimSpace(req.Platform))
+	switch platform {
+	case PlatformIOS, PlatformAndroid, PlatformWeb:
+	default:
+		platform = PlatformUnknown
+	}
+
+	// 예전 방식(notificationAgrees.device_id)으로 저장된 다른 토큰은 디바이스 목록으로 옮김
+	agree, err := u.settingModel.GetNotificationAgree(userId)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+	if agree != nil && agree.DeviceId != nil && *agree.DeviceId != "" && *agree.DeviceId != req.DeviceId {
+		_, err = u.deviceModel.UpsertDevice(userId, *agree.DeviceId, PlatformUnknown, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	device, err := u.deviceModel.UpsertDevice(userId, req.DeviceId, platform, req.AppVersion)
 	if err != nil {
 		return nil, err
 	}
 
 	// 알림동의가 디폴트 Y 로 저장
-	if result.UpsertedCount == 1 {
-		pushNotification := PushNotificationReq{IsReadAgree: true, IsClassifyAgree: true}
-		_, err := u.settingModel.UpdatePushNotification(pushNotification, userId)
+	_, err = u.settingModel.CreateDefaultNotificationAgree(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+func (u SettingUsecase) GetDevices(userId string) ([]Device, error) {
+	return u.deviceModel.GetDevices(userId)
+}
+
+func (u SettingUsecase) DeleteDevice(userId, id string) error {
+	result, err := u.deviceModel.DeleteDevice(userId, id)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Logout 은 로그아웃한 디바이스로 더 이상 푸시가 가지 않도록 지우고, 지운 디바이스 수를 반환합니다.
+// deviceId(토큰)를 보내지 않은 예전 앱은 모든 디바이스를 지웁니다.
+func (u SettingUsecase) Logout(userId, deviceId string) (int64, error) {
+	if deviceId != "" {
+		removed, err := u.settingModel.DeleteDeviceToken(userId, deviceId)
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
+		if removed {
+			return 1, nil
+		}
+		return 0, nil
 	}
 
-	return result, nil
+	result, err := u.deviceModel.DeleteDevices(userId)
+	if err != nil {
+		return 0, err
+	}
+
+	// 예전 방식으로 저장된 토큰
+	_, err = u.settingModel.DeleteDivceId(userId)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
 }
 
+// GetNotificationAgree 는 알림 설정을 반환합니다. 설정하지 않은 값은 기본값(Asia/Seoul, ko, 19시, 종류별 주 1회)으로 채웁니다.
 func (u SettingUsecase) GetNotificationAgree(userId string) (*NotificationAgree, error) {
 	result, err := u.settingModel.GetNotificationAgree(userId)
 	if err != nil {
@@ -35,17 +101,85 @@ func (u SettingUsecase) GetNotificationAgree(userId string) (*NotificationAgree,
 				IsClassifyAgree: false,
 				UserId:          userId,
 			}
-			return agree, nil
+			return withNotificationDefaults(agree), nil
 		}
 		return nil, err
 	}
-	return result, nil
+	return withNotificationDefaults(result), nil
 }
 
+// UpdatePushNotification 은 요청에 있는 값만 현재 설정에 반영해 저장합니다.
+// 종류별 푸시 동의와 예전 동의 여부(isReadAgree, isClassifyAgree)는 항상 같은 값으로 저장합니다.
 func (u SettingUsecase) UpdatePushNotification(req PushNotificationReq, userId string) (*mongo.UpdateResult, error) {
-	result, err := u.settingModel.UpdatePushNotification(req, userId)
+	if err := ValidateNotificationSettings(req); err != nil {
+		return nil, err
+	}
+
+	agree, err := u.GetNotificationAgree(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IsReadAgree != nil {
+		pref := agree.Preferences[NotificationTypeUnread]
+		pref.Push = *req.IsReadAgree
+		agree.Preferences[NotificationTypeUnread] = pref
+	}
+	if req.IsClassifyAgree != nil {
+		pref := agree.Preferences[NotificationTypeUnclassified]
+		pref.Push = *req.IsClassifyAgree
+		agree.Preferences[NotificationTypeUnclassified] = pref
+	}
+	for notificationType, reqPref := range req.Preferences {
+		pref := agree.Preferences[notificationType]
+		if reqPref.Push != nil {
+			pref.Push = *reqPref.Push
+		}
+		if reqPref.Frequency != "" {
+			pref.Frequency = reqPref.Frequency
+		}
+		agree.Preferences[notificationType] = pref
+	}
+	agree.IsReadAgree = agree.Preferences[NotificationTypeUnread].Push
+	agree.IsClassifyAgree = agree.Preferences[NotificationTypeUnclassified].Push
+
+	if req.Timezone != nil {
+		agree.Timezone = *req.Timezone
+	}
+	if req.Locale != nil {
+		agree.Locale = i18n.ResolveLocale(*req.Locale)
+	}
+	if req.NotifyHour != nil {
+		agree.NotifyHour = req.NotifyHour
+	}
+	if req.QuietHours != nil {
+		agree.QuietHours = req.QuietHours
+		if req.QuietHours.Start == "" && req.QuietHours.End == "" {
+			agree.QuietHours = nil
+		}
+	}
+
+	result, err := u.settingModel.UpdatePushNotification(*agree, userId)
 	if err != nil {
 		return nil, err
 	}
 	return result, nil
 }
+
+func withNotificationDefaults(agree *NotificationAgree) *NotificationAgree {
+	preferences := make(map[string]NotificationPreference, len(NotificationTypes))
+	for _, notificationType := range NotificationTypes {
+		preferences[notificationType] = agree.Preference(notificationType)
+	}
+	agree.Preferences = preferences
+
+	if agree.Timezone == "" {
+		agree.Timezone = DefaultTimezone
+	}
+	agree.Locale = i18n.ResolveLocale(agree.Locale)
+	if agree.NotifyHour == nil {
+		notifyHour := DefaultNotifyHour
+		agree.NotifyHour = &notifyHour
+	}
+	return agree
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type UserModel struct{}
@@ -126,6 +127,34 @@ func (*UserModel) FindUsers() ([]*User, error) {
 	return users, nil
 }
 
+// FindUserIds 는 afterUserId 다음 사용자 아이디를 user_id 순으로 최대 limit 개 반환합니다. (전체 사용자를 나누어 처리할 때)
+func (*UserModel) FindUserIds(afterUserId string, limit int64) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": bson.M{"$gt": afterUserId}}
+	opts := options.Find().
+		SetSort(bson.M{"user_id": 1}).
+		SetLimit(limit).
+		SetProjection(bson.M{"user_id": 1})
+
+	cursor, err := db.UserCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	userIds := make([]string, 0, len(users))
+	for _, user := range users {
+		userIds = append(userIds, user.UserId)
+	}
+	return userIds, nil
+}
+
 func (*UserModel) FindInactiveUser(email string) (*InactiveUser, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
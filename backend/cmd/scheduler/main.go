@@ -1,29 +1,170 @@
+// scheduler 는 알림 등 주기 작업을 실행하는 프로세스입니다.
+// 작업은 job/scheduler 의 DefaultJobs 에 등록하고, 실행 주기는 scheduler-config.yml 에 cron 표현식(분 시 일 월 요일)으로 적습니다.
+//
+//	schedulerTimezone: Asia/Seoul
+//	jobs:
+//	  unread:
+//	    schedule: "0 * * * *"    # 매시 정각
+//	  unclassified:
+//	    schedule: "0 * * * *"
+//	  reminder:
+//	    schedule: "*/10 * * * *" # 10분마다. 리마인더는 예약한 시각 이후 첫 실행에서 보냄
+//	  embedding:
+//	    schedule: "30 * * * *"   # 시맨틱 검색용 링크 임베딩. 링크 저장/수정 때 못 만든 임베딩을 채움
+//	notificationWorkers: 10      # 동시에 처리하는 사용자 수
+//	pushProvider: fcm            # 기본 푸시 제공자 (fcm, apns, fake)
+//	pushProviders:
+//	  ios: apns                  # 플랫폼별 제공자 (ios, android, web, unknown). iOS 앱이 APNs 토큰을 등록할 때만
+//	pushRatePerSecond: 50        # 제공자별 초당 요청 수
+//	pushTimeoutSeconds: 10       # 요청 제한 시간
+//	pushMaxRetries: 3            # 429, 5xx, 네트워크 오류 재시도 횟수
+//	fcmCredentialsFile: fireBaseKey.json
+//	apnsKeyFile: AuthKey.p8      # apnsKeyId, apnsTeamId, apnsTopic(번들 ID), apnsSandbox 와 함께 설정
+//
+// 알림 작업은 실행할 때마다 사용자별 알림 설정(시간대, 알림 시각, 조용한 시간, 종류별 빈도)으로 보낼 사용자를 고릅니다.
+// 사용자 시간대의 알림 시각에 맞추려면 매시 실행하고, 실행 주기가 길면 그만큼 늦게 보내거나 빈도(daily)를 지키지 못합니다.
+//
+// 사용 예:
+//
+//	go run ./cmd/scheduler               // 서비스로 실행 (serve 와 같음)
+//	go run ./cmd/scheduler list          // 작업 목록, 실행 주기, 다음 실행 시각, 최근 실행 결과
+//	go run ./cmd/scheduler run unread    // 작업을 지금 한 번 실행
+//	go run ./cmd/scheduler history unread
+//
+// 여러 인스턴스를 띄워도 jobLocks 컬렉션의 작업별 잠금으로 한 곳에서만 실행되고, 알림은 실행별 멱등성 키로 사용자당 한 번만 저장/발송됩니다.
+// 종료 신호나 프로세스 종료로 중단된 실행은 다음 실행(또는 스케줄러 시작 시)에서 같은 키로 이어서 처리합니다.
+//
+// 배포할 때는 Dockerfile.scheduler 이미지를 serve 로 계속 띄워 둡니다. (.github/workflows/deploy.yml)
+// 컨테이너를 멈추면(SIGTERM) 실행 중인 작업이 끝날 때까지 기다린 뒤 종료합니다.
+//
+// 실행 기록은 jobRuns 컬렉션에 남습니다. 이전처럼 작업 이름만 인자로 주면(scheduler unread) run 과 같이 한 번 실행합니다.
 package main
 
 import (
-	"joosum-backend/job/notification"
-	"joosum-backend/pkg/config"
-	"joosum-backend/pkg/util"
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"joosum-backend/job/scheduler"
+	"joosum-backend/pkg/config"
+	"joosum-backend/pkg/util"
 )
 
 func main() {
 	config.EnvSchedulerConfig()
 	util.StartMongoDB()
+	defer util.CloseMongoDB()
+
+	s, err := scheduler.NewScheduler(scheduler.DefaultJobs())
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	var notificationType string
+	command := "serve"
+	var args []string
 	if len(os.Args) > 1 {
-		notificationType = os.Args[1]
+		command = os.Args[1]
+		args = os.Args[2:]
+	}
+
+	switch command {
+	case "serve":
+		serve(s)
+	case "list":
+		list(s)
+	case "run":
+		if len(args) != 1 {
+			log.Fatal("usage: scheduler run <job>")
+		}
+		runOnce(s, args[0])
+	case "history":
+		jobName := ""
+		if len(args) > 0 {
+			jobName = args[0]
+		}
+		history(jobName)
+	default:
+		// 외부 cron 에서 scheduler <작업 이름> 으로 실행하던 방식
+		runOnce(s, command)
+	}
+}
+
+func serve(s *scheduler.Scheduler) {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := s.Start(ctx); err != nil {
+		log.Fatal(err)
+	}
+
+	<-ctx.Done()
+	log.Println("[스케줄러] 종료 신호를 받았습니다. 실행 중인 작업이 끝나기를 기다립니다.")
+	s.Wait()
+}
+
+func runOnce(s *scheduler.Scheduler, jobName string) {
+	run, err := s.RunJob(context.Background(), jobName, scheduler.TriggerManual)
+	if run == nil {
+		log.Fatal(err)
 	}
+	if err != nil {
+		// 실행 기록은 남았으므로 종료 코드로만 실패를 알립니다.
+		util.CloseMongoDB()
+		os.Exit(1)
+	}
+}
+
+func list(s *scheduler.Scheduler) {
+	jobRunModel := scheduler.JobRunModel{}
+	now := time.Now().In(s.Location())
+
+	for _, job := range s.Jobs() {
+		scheduleText, nextText := "-", "-"
+		schedule, err := s.JobSchedule(job.Name)
+		if err != nil {
+			scheduleText = "invalid: " + err.Error()
+		} else if schedule != nil {
+			scheduleText = schedule.String()
+			nextText = schedule.Next(now).Format(time.RFC3339)
+		}
+
+		fmt.Printf("%s\t%s\n", job.Name, job.Description)
+		fmt.Printf("\tschedule: %s\n\tnext:     %s\n", scheduleText, nextText)
+
+		runs, err := jobRunModel.GetRecentJobRuns(job.Name, 1)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(runs) > 0 {
+			fmt.Printf("\tlast run: %s\n", formatRun(runs[0]))
+		}
+	}
+}
+
+func history(jobName string) {
+	runs, err := scheduler.JobRunModel{}.GetRecentJobRuns(jobName, 20)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, run := range runs {
+		fmt.Printf("%s\t%s\n", run.JobName, formatRun(run))
+		if run.Error != "" {
+			fmt.Printf("\terror: %s\n", run.Error)
+		}
+	}
+}
 
-	if notificationType == notification.Unread {
-		notification.SendUnreadLink()
-	} else if notificationType == notification.Unclassified {
-		notification.SendUnclassifiedLink()
-	} else {
-		log.Fatal("invalid notificationType")
+func formatRun(run scheduler.JobRun) string {
+	duration := "-"
+	if run.EndedAt != nil {
+		duration = run.EndedAt.Sub(run.StartedAt).Round(time.Millisecond).String()
 	}
 
-	util.CloseMongoDB()
+	return fmt.Sprintf("%s %s (%s, %s) processed=%d succeeded=%d failed=%d",
+		run.StartedAt.Format(time.RFC3339), run.Status, run.Trigger, duration, run.Processed, run.Succeeded, run.Failed)
 }
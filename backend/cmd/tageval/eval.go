@@ -0,0 +1,307 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"joosum-backend/app/aiprompt"
+	"joosum-backend/app/aiusage"
+	"joosum-backend/app/link"
+	"joosum-backend/pkg/config"
+	"joosum-backend/pkg/llm"
+	"joosum-backend/pkg/tagpolicy"
+)
+
+// evalCase 는 cases.json 의 평가 케이스 하나입니다.
+type evalCase struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// 픽스처 디렉터리 기준 HTML 파일 경로
+	HTML string `json:"html"`
+	// 기대 태그. "제네릭|Generics" 처럼 | 로 같은 의미의 표기를 함께 적을 수 있습니다.
+	ExpectedTags []string `json:"expectedTags"`
+	// 사용자가 이미 가진 태그 (추천 태그를 이 태그로 맞춤)
+	UserTags []string `json:"userTags"`
+	// provider=fake 일 때 LLM 응답으로 사용합니다. (API 호출 없이 평가 도구 자체를 확인할 때)
+	FakeResponse string `json:"fakeResponse"`
+	// provider=fake 일 때 기존 태그 맞춤 요청의 응답. 없으면 맞춘 태그가 없는 응답을 사용합니다.
+	FakeMatchResponse string `json:"fakeMatchResponse"`
+}
+
+// evalConfig 는 비교할 설정 하나입니다. (제공자, 모델, 프롬프트 버전)
+type evalConfig struct {
+	Label         string
+	Provider      string
+	Model         string
+	PromptVersion string
+}
+
+type caseResult struct {
+	Case    evalCase
+	Tags    []string
+	Matched int
+	// 정책 필터(tagpolicy.Filter)에서 제외된 모델 출력
+	Rejected []tagpolicy.Rejected
+	// 필터를 거친 최종 태그 중 정책을 어기는 태그별 위반 유형 (필터가 놓친 경우)
+	Violations map[string][]string
+	Err        error
+	Latency    time.Duration
+}
+
+type configResult struct {
+	Config           evalConfig
+	Cases            []caseResult
+	Expected         int
+	Predicted        int
+	Matched          int
+	RawTags          int
+	RejectedTags     int
+	ViolatingTags    int
+	ViolationCounts  map[string]int
+	Failed           int
+	TotalLatency     time.Duration
+	PromptTokens     int
+	CompletionTokens int
+	CostUSD          float64
+}
+
+func (r configResult) Precision() float64 {
+	return ratio(r.Matched, r.Predicted)
+}
+
+func (r configResult) Recall() float64 {
+	return ratio(r.Matched, r.Expected)
+}
+
+func (r configResult) F1() float64 {
+	return f1(r.Precision(), r.Recall())
+}
+
+func (r caseResult) Precision() float64 {
+	return ratio(r.Matched, len(r.Tags))
+}
+
+func (r caseResult) Recall() float64 {
+	return ratio(r.Matched, len(r.Case.ExpectedTags))
+}
+
+// parseConfig 는 "provider=openai,model=gpt-4o-mini,prompt=v2,label=기준" 형식의 설정을 읽습니다.
+// 빠진 값은 config.yml 의 llmProvider, llmModel 과 기본 프롬프트 버전을 사용합니다.
+func parseConfig(spec string) (evalConfig, error) {
+	cfg := evalConfig{
+		Provider:      config.GetEnvConfig("llmProvider"),
+		Model:         config.GetEnvConfig("llmModel"),
+		PromptVersion: aiprompt.DefaultVersion(aiprompt.NameAITags),
+	}
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return cfg, fmt.Errorf("invalid config %q: expected key=value", part)
+		}
+
+		switch strings.TrimSpace(key) {
+		case "provider":
+			cfg.Provider = strings.TrimSpace(value)
+		case "model":
+			cfg.Model = strings.TrimSpace(value)
+		case "prompt":
+			cfg.PromptVersion = strings.TrimSpace(value)
+		case "label":
+			cfg.Label = strings.TrimSpace(value)
+		default:
+			return cfg, fmt.Errorf("unknown config key %q", key)
+		}
+	}
+
+	if cfg.Provider == "" {
+		cfg.Provider = llm.ProviderOpenAI
+	}
+	if cfg.Model == "" {
+		cfg.Model = llm.DefaultModel
+	}
+	if cfg.Provider == llm.ProviderFake {
+		cfg.Model = llm.ProviderFake
+	}
+	if cfg.Label == "" {
+		cfg.Label = fmt.Sprintf("%s/%s/%s", cfg.Provider, cfg.Model, cfg.PromptVersion)
+	}
+
+	return cfg, nil
+}
+
+func loadCases(dir string) ([]evalCase, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "cases.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var cases []evalCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, fmt.Errorf("failed to parse cases.json: %v", err)
+	}
+
+	return cases, nil
+}
+
+// runConfig 는 모든 케이스에 대해 태그 추천 파이프라인을 실행하고 점수를 매깁니다.
+func runConfig(cfg evalConfig, cases []evalCase, fixtureDir string, promptDir string) (*configResult, error) {
+	prompts, err := aiprompt.LoadPromptFiles(aiprompt.NameAITags, promptDir)
+	if err != nil {
+		return nil, err
+	}
+	prompt, ok := prompts[cfg.PromptVersion]
+	if !ok {
+		return nil, fmt.Errorf("prompt version %s not found (builtin or -prompt-dir)", cfg.PromptVersion)
+	}
+
+	var baseProvider llm.LLMProvider
+	if cfg.Provider != llm.ProviderFake {
+		baseProvider, err = llm.NewProviderByName(cfg.Provider, cfg.Model)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &configResult{Config: cfg, ViolationCounts: map[string]int{}}
+
+	for _, c := range cases {
+		provider := baseProvider
+		if cfg.Provider == llm.ProviderFake {
+			provider = newFakeCaseProvider(c)
+		}
+		provider = llm.WithUsageRecorder(provider, func(resp *llm.ChatResponse) {
+			result.PromptTokens += resp.PromptTokens
+			result.CompletionTokens += resp.CompletionTokens
+			result.CostUSD += aiusage.EstimateCost(resp.Model, resp.PromptTokens, resp.CompletionTokens)
+		})
+
+		cr := runCase(provider, &prompt, c, fixtureDir)
+
+		result.Cases = append(result.Cases, cr)
+		result.Expected += len(c.ExpectedTags)
+		result.Predicted += len(cr.Tags)
+		result.RawTags += len(cr.Tags) + len(cr.Rejected)
+		result.Matched += cr.Matched
+		result.TotalLatency += cr.Latency
+		if cr.Err != nil {
+			result.Failed++
+		}
+		for _, r := range cr.Rejected {
+			result.RejectedTags++
+			for _, v := range r.Reasons {
+				result.ViolationCounts[v]++
+			}
+		}
+		for _, violations := range cr.Violations {
+			result.ViolatingTags++
+			for _, v := range violations {
+				result.ViolationCounts[v]++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// newFakeCaseProvider 는 첫 요청(태그 추천)에는 fakeResponse, 다음 요청(기존 태그 맞춤)에는 fakeMatchResponse 로 응답하는 제공자를 만듭니다
+func newFakeCaseProvider(c evalCase) *llm.FakeProvider {
+	responses := []string{c.FakeResponse, c.FakeMatchResponse}
+	if responses[0] == "" {
+		responses[0] = "[]"
+	}
+	if responses[1] == "" {
+		responses[1] = `{"matches": []}`
+	}
+
+	calls := 0
+	return &llm.FakeProvider{Respond: func(req llm.ChatRequest) (string, error) {
+		response := responses[len(responses)-1]
+		if calls < len(responses) {
+			response = responses[calls]
+		}
+		calls++
+		return response, nil
+	}}
+}
+
+func runCase(provider llm.LLMProvider, prompt *aiprompt.AIPrompt, c evalCase, fixtureDir string) caseResult {
+	cr := caseResult{Case: c, Violations: map[string][]string{}}
+
+	file, err := os.Open(filepath.Join(fixtureDir, c.HTML))
+	if err != nil {
+		cr.Err = err
+		return cr
+	}
+	defer file.Close()
+
+	start := time.Now()
+	recommended, rejected, err := link.RecommendTagsFromHTML(provider, prompt, file, c.URL, c.UserTags)
+	cr.Latency = time.Since(start)
+	if err != nil {
+		cr.Err = err
+		return cr
+	}
+
+	cr.Rejected = rejected
+	for _, t := range recommended {
+		cr.Tags = append(cr.Tags, t.Name)
+		if violations := tagpolicy.Check(t.Name, c.URL); len(violations) > 0 {
+			cr.Violations[t.Name] = violations
+		}
+	}
+	cr.Matched = countMatches(c.ExpectedTags, cr.Tags)
+
+	return cr
+}
+
+// countMatches 는 기대 태그 중 추천된 태그 수를 셉니다. 대소문자, 공백, -, _ 차이는 무시합니다.
+// 추천 태그 하나는 기대 태그 하나에만 매칭됩니다.
+func countMatches(expected []string, predicted []string) int {
+	used := make([]bool, len(predicted))
+	matched := 0
+
+	for _, e := range expected {
+		alternatives := map[string]bool{}
+		for _, alt := range strings.Split(e, "|") {
+			alternatives[matchKey(alt)] = true
+		}
+
+		for i, p := range predicted {
+			if !used[i] && alternatives[matchKey(p)] {
+				used[i] = true
+				matched++
+				break
+			}
+		}
+	}
+
+	return matched
+}
+
+func matchKey(tag string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(tag)))
+}
+
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+func f1(precision, recall float64) float64 {
+	if precision+recall == 0 {
+		return 0
+	}
+	return 2 * precision * recall / (precision + recall)
+}
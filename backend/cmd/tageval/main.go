@@ -0,0 +1,75 @@
+// tageval 은 저장해 둔 HTML 픽스처로 AI 태그 추천 파이프라인을 실행해 품질을 측정합니다.
+// 기대 태그 대비 Precision/Recall 과 ai_tag_policy.md 위반(도메인명, 날짜, 이모지, 문장형, 10자 초과 등)을 계산하고,
+// 두 설정(-a, -b)을 주면 차이를 비교한 보고서를 출력합니다.
+//
+// 사용 예:
+//
+//	go run ./cmd/tageval -a "model=gpt-4o-mini,prompt=v2" -b "model=gpt-4.1-mini,prompt=v2"
+//	go run ./cmd/tageval -a "prompt=v2" -b "prompt=v3" -prompt-dir ./prompts
+//	go run ./cmd/tageval -a "provider=fake"   // 픽스처의 fakeResponse 사용, API 호출 없음
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"joosum-backend/pkg/config"
+)
+
+func main() {
+	fixtureDir := flag.String("fixtures", "cmd/tageval/testdata", "cases.json 과 HTML 픽스처가 있는 디렉터리")
+	specA := flag.String("a", "", "설정 A (provider=,model=,prompt=,label=)")
+	specB := flag.String("b", "", "비교할 설정 B (비우면 A 만 평가)")
+	promptDir := flag.String("prompt-dir", "", "내장 프롬프트 외에 읽을 프롬프트 디렉터리 (<dir>/ai_tags/<버전>/{system,user}.tmpl)")
+	out := flag.String("out", "", "보고서 파일 경로 (비우면 표준 출력)")
+	flag.Parse()
+
+	// API 키 등은 config.yml 에서 읽습니다. fake 제공자만 쓸 때는 없어도 됩니다.
+	if _, err := os.Stat("config.yml"); err == nil {
+		config.EnvConfig()
+	}
+
+	specs := []string{*specA}
+	if *specB != "" {
+		specs = append(specs, *specB)
+	}
+
+	cases, err := loadCases(*fixtureDir)
+	if err != nil {
+		log.Fatalf("픽스처를 읽지 못했습니다: %v", err)
+	}
+
+	var results []*configResult
+	for _, spec := range specs {
+		cfg, err := parseConfig(spec)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		log.Printf("평가 시작: %s (%d건)", cfg.Label, len(cases))
+		result, err := runConfig(cfg, cases, *fixtureDir, *promptDir)
+		if err != nil {
+			log.Fatalf("%s 평가 실패: %v", cfg.Label, err)
+		}
+		results = append(results, result)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	writeReport(w, *fixtureDir, len(cases), results)
+
+	if *out != "" {
+		fmt.Printf("보고서를 %s 에 저장했습니다.\n", *out)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// writeReport 는 설정별 결과를 마크다운 표로 씁니다. 설정이 두 개면 B-A 차이를 함께 보여줍니다.
+func writeReport(w io.Writer, fixtureDir string, caseCount int, results []*configResult) {
+	compare := len(results) == 2
+
+	fmt.Fprintf(w, "# AI 태그 평가 결과\n\n")
+	fmt.Fprintf(w, "- 픽스처: `%s` (%d건)\n", fixtureDir, caseCount)
+	fmt.Fprintf(w, "- 실행 시각: %s\n\n", time.Now().Format("2006-01-02 15:04:05"))
+
+	header := "| 항목 |"
+	divider := "| --- |"
+	for i := range results {
+		header += fmt.Sprintf(" %s |", configName(i))
+		divider += " --- |"
+	}
+	if compare {
+		header += " 차이 (B-A) |"
+		divider += " --- |"
+	}
+	fmt.Fprintln(w, header)
+	fmt.Fprintln(w, divider)
+
+	row := func(name string, value func(r *configResult) string, diff func(a, b *configResult) string) {
+		line := "| " + name + " |"
+		for _, r := range results {
+			line += " " + value(r) + " |"
+		}
+		if compare {
+			d := ""
+			if diff != nil {
+				d = diff(results[0], results[1])
+			}
+			line += " " + d + " |"
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	metricDiff := func(metric func(r *configResult) float64) func(a, b *configResult) string {
+		return func(a, b *configResult) string {
+			return fmt.Sprintf("%+.3f", metric(b)-metric(a))
+		}
+	}
+
+	row("설정", func(r *configResult) string { return "`" + r.Config.Label + "`" }, nil)
+	row("Precision", func(r *configResult) string { return fmt.Sprintf("%.3f", r.Precision()) },
+		metricDiff(func(r *configResult) float64 { return r.Precision() }))
+	row("Recall", func(r *configResult) string { return fmt.Sprintf("%.3f", r.Recall()) },
+		metricDiff(func(r *configResult) float64 { return r.Recall() }))
+	row("F1", func(r *configResult) string { return fmt.Sprintf("%.3f", r.F1()) },
+		metricDiff(func(r *configResult) float64 { return r.F1() }))
+	row("필터로 제외된 모델 출력", func(r *configResult) string { return fmt.Sprintf("%d / %d", r.RejectedTags, r.RawTags) },
+		func(a, b *configResult) string { return fmt.Sprintf("%+d", b.RejectedTags-a.RejectedTags) })
+	row("최종 태그 정책 위반", func(r *configResult) string { return fmt.Sprintf("%d / %d", r.ViolatingTags, r.Predicted) },
+		func(a, b *configResult) string { return fmt.Sprintf("%+d", b.ViolatingTags-a.ViolatingTags) })
+	row("실패한 케이스", func(r *configResult) string { return fmt.Sprintf("%d", r.Failed) },
+		func(a, b *configResult) string { return fmt.Sprintf("%+d", b.Failed-a.Failed) })
+	row("평균 응답 시간", func(r *configResult) string { return averageLatency(r).String() },
+		func(a, b *configResult) string { return (averageLatency(b) - averageLatency(a)).String() })
+	row("토큰 (입력/출력)", func(r *configResult) string { return fmt.Sprintf("%d / %d", r.PromptTokens, r.CompletionTokens) }, nil)
+	row("예상 비용 (USD)", func(r *configResult) string { return fmt.Sprintf("%.5f", r.CostUSD) },
+		func(a, b *configResult) string { return fmt.Sprintf("%+.5f", b.CostUSD-a.CostUSD) })
+
+	writeViolationTable(w, results)
+	writeCaseDetails(w, results)
+}
+
+func writeViolationTable(w io.Writer, results []*configResult) {
+	types := map[string]bool{}
+	for _, r := range results {
+		for v := range r.ViolationCounts {
+			types[v] = true
+		}
+	}
+	if len(types) == 0 {
+		return
+	}
+
+	sorted := make([]string, 0, len(types))
+	for v := range types {
+		sorted = append(sorted, v)
+	}
+	sort.Strings(sorted)
+
+	fmt.Fprintf(w, "\n## 정책 위반 유형 (필터 제외 + 최종 태그)\n\n")
+	header := "| 유형 |"
+	divider := "| --- |"
+	for i := range results {
+		header += " " + configName(i) + " |"
+		divider += " --- |"
+	}
+	fmt.Fprintln(w, header)
+	fmt.Fprintln(w, divider)
+
+	for _, v := range sorted {
+		line := "| " + v + " |"
+		for _, r := range results {
+			line += fmt.Sprintf(" %d |", r.ViolationCounts[v])
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func writeCaseDetails(w io.Writer, results []*configResult) {
+	fmt.Fprintf(w, "\n## 케이스별 결과\n")
+
+	for i, c := range results[0].Cases {
+		fmt.Fprintf(w, "\n### %s\n\n", c.Case.Name)
+		fmt.Fprintf(w, "- URL: %s\n", c.Case.URL)
+		fmt.Fprintf(w, "- 기대 태그: %s\n", strings.Join(c.Case.ExpectedTags, ", "))
+
+		for j, r := range results {
+			cr := r.Cases[i]
+			if cr.Err != nil {
+				fmt.Fprintf(w, "- %s: 실패 (%v)\n", configName(j), cr.Err)
+				continue
+			}
+			fmt.Fprintf(w, "- %s (P %.2f / R %.2f): %s\n", configName(j), cr.Precision(), cr.Recall(), formatTags(cr))
+		}
+	}
+}
+
+// formatTags 는 최종 태그 뒤에 필터로 제외된 태그를 이유와 함께 보여줍니다. 예: 프롬프트, 기획자 / 제외: ~~brunch~~ (domain)
+// 최종 태그가 정책을 어기면 ⚠ 와 위반 유형을 붙입니다.
+func formatTags(cr caseResult) string {
+	parts := make([]string, 0, len(cr.Tags))
+	for _, t := range cr.Tags {
+		if violations, ok := cr.Violations[t]; ok {
+			parts = append(parts, fmt.Sprintf("%s ⚠ (%s)", t, strings.Join(violations, ", ")))
+			continue
+		}
+		parts = append(parts, t)
+	}
+
+	text := strings.Join(parts, ", ")
+	if text == "" {
+		text = "(없음)"
+	}
+
+	if len(cr.Rejected) > 0 {
+		rejected := make([]string, 0, len(cr.Rejected))
+		for _, r := range cr.Rejected {
+			rejected = append(rejected, fmt.Sprintf("~~%s~~ (%s)", r.Tag, strings.Join(r.Reasons, ", ")))
+		}
+		text += " / 제외: " + strings.Join(rejected, ", ")
+	}
+
+	return text
+}
+
+func averageLatency(r *configResult) time.Duration {
+	if len(r.Cases) == 0 {
+		return 0
+	}
+	return (r.TotalLatency / time.Duration(len(r.Cases))).Round(time.Millisecond)
+}
+
+func configName(i int) string {
+	return string(rune('A' + i))
+}
@@ -0,0 +1,79 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"strconv"
+	"sync"
+
+	"joosum-backend/app/setting"
+	"joosum-backend/pkg/config"
+)
+
+// 동시에 처리하는 사용자 수 기본값. scheduler-config.yml 의 notificationWorkers 로 바꿀 수 있습니다.
+// 푸시 제공자별 초당 요청 수, 제한 시간, 재시도는 pkg/push 의 설정(pushRatePerSecond 등)을 따릅니다.
+const defaultWorkers = 10
+
+func notificationWorkers() int {
+	n, err := strconv.Atoi(config.GetEnvConfig("notificationWorkers"))
+	if err != nil || n <= 0 {
+		return defaultWorkers
+	}
+	return n
+}
+
+// forEachAgree 는 workers 개의 고루틴으로 알림 동의 정보마다 fn 을 실행합니다.
+// fn 에서 panic 이 나도 해당 사용자만 실패로 기록(onPanic)하고 나머지는 계속 처리합니다.
+// ctx 가 취소되면 새 사용자를 넘기지 않고, 처리 중인 사용자가 끝나면 ctx.Err() 를 반환합니다.
+func forEachAgree(ctx context.Context, workers int, agrees []setting.NotificationAgree,
+	fn func(agree setting.NotificationAgree), onPanic func(agree setting.NotificationAgree, err error)) error {
+
+	return forEachUser(ctx, workers, agrees, func(agree setting.NotificationAgree) string { return agree.UserId }, fn, onPanic)
+}
+
+// forEachUser 는 forEachAgree 와 같은 방식으로 사용자별 항목(알림 동의 정보, 리마인더)마다 fn 을 실행합니다.
+// userId 는 panic 로그에 남길 사용자 아이디입니다.
+func forEachUser[T any](ctx context.Context, workers int, items []T, userId func(item T) string,
+	fn func(item T), onPanic func(item T, err error)) error {
+
+	queue := make(chan T)
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				runItem(item, userId, fn, onPanic)
+			}
+		}()
+	}
+
+	var err error
+feed:
+	for _, item := range items {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			break feed
+		case queue <- item:
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	return err
+}
+
+func runItem[T any](item T, userId func(item T) string, fn func(item T), onPanic func(item T, err error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[알림] 사용자 처리 중 panic (userId=%s): %v\n%s", userId(item), r, debug.Stack())
+			onPanic(item, fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	fn(item)
+}
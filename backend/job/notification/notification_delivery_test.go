@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"joosum-backend/app/setting"
+	"joosum-backend/pkg/push"
+)
+
+func TestForEachAgreeIsolatesFailures(t *testing.T) {
+	agrees := []setting.NotificationAgree{
+		{UserId: "user-1"}, {UserId: "user-2"}, {UserId: "user-3"}, {UserId: "user-4"}, {UserId: "user-5"},
+	}
+
+	for _, workers := range []int{1, 3} {
+		var mu sync.Mutex
+		var done []string
+		var panicked []string
+
+		// user-2 는 처리 중 panic, user-4 는 푸시 발송 실패
+		sender := push.NewFakeSender()
+		sender.Fail = func(msg push.Message) error {
+			if msg.Token == "token-user-4" {
+				return errors.New("push failed")
+			}
+			return nil
+		}
+
+		err := forEachAgree(context.Background(), workers, agrees, func(agree setting.NotificationAgree) {
+			if agree.UserId == "user-2" {
+				panic("unexpected")
+			}
+			if err := sender.Send(context.Background(), push.Message{Token: "token-" + agree.UserId}); err != nil {
+				return
+			}
+
+			mu.Lock()
+			done = append(done, agree.UserId)
+			mu.Unlock()
+		}, func(agree setting.NotificationAgree, err error) {
+			mu.Lock()
+			panicked = append(panicked, agree.UserId)
+			mu.Unlock()
+		})
+
+		if err != nil {
+			t.Fatalf("workers=%d: err = %v", workers, err)
+		}
+
+		sort.Strings(done)
+		if want := []string{"user-1", "user-3", "user-5"}; !equalStrings(done, want) {
+			t.Errorf("workers=%d: 처리한 사용자 = %v, want %v", workers, done, want)
+		}
+		if want := []string{"user-2"}; !equalStrings(panicked, want) {
+			t.Errorf("workers=%d: panic 사용자 = %v, want %v", workers, panicked, want)
+		}
+		if got := len(sender.Sent()); got != 3 {
+			t.Errorf("workers=%d: 보낸 푸시 수 = %d, want 3", workers, got)
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,41 +1,62 @@
 package notification
 
 import (
+	"context"
+	"fmt"
 	"log"
-)
 
-const firebaseScope = "https://www.googleapis.com/auth/firebase.messaging"
+	"joosum-backend/app/setting"
+	"joosum-backend/pkg/push"
+)
 
-func SendUnreadLink() {
+// SendUnreadLink 는 읽지 않은 링크 알림을 보냅니다. runKey 는 실행의 멱등성 키입니다.
+func SendUnreadLink(ctx context.Context, runKey string) (SendResult, error) {
 
 	// 1. device token 가져옴
 	notificationAgrees, err := getNotificationAgrees()
 	if err != nil {
-		panic("알림동의 목록을 가져오는데 실패했습니다: " + err.Error())
+		return SendResult{}, fmt.Errorf("알림동의 목록을 가져오는데 실패했습니다: %v", err)
 	}
 	log.Printf("%d 개의 알림동의 정보를 가져왔습니다.\n\n", len(notificationAgrees))
 
-	// 2. 알림 보냄, 저장
-	err = SendUnreadLinks(notificationAgrees)
+	// 2. 플랫폼별 푸시 제공자 준비
+	senders, err := push.NewSendersFromConfig(setting.Platforms)
+	if err != nil {
+		return SendResult{}, fmt.Errorf("푸시 제공자를 준비하는데 실패했습니다: %v", err)
+	}
+
+	// 3. 알림 보냄, 저장
+	result, err := SendUnreadLinks(ctx, runKey, notificationAgrees, senders)
 	if err != nil {
-		panic("failed to send or save notifications: " + err.Error())
+		return result, fmt.Errorf("failed to send or save notifications: %v", err)
 	}
 	log.Println("END")
+
+	return result, nil
 }
 
-func SendUnclassifiedLink() {
+// SendUnclassifiedLink 는 분류되지 않은 링크 알림을 보냅니다. runKey 는 실행의 멱등성 키입니다.
+func SendUnclassifiedLink(ctx context.Context, runKey string) (SendResult, error) {
 
 	// 1. device token 가져옴
 	notificationAgrees, err := getNotificationAgrees()
 	if err != nil {
-		panic("알림동의 목록을 가져오는데 실패했습니다: " + err.Error())
+		return SendResult{}, fmt.Errorf("알림동의 목록을 가져오는데 실패했습니다: %v", err)
 	}
 	log.Printf("%d 개의 알림동의 정보를 가져왔습니다.\n\n", len(notificationAgrees))
 
-	// 2. 알림 보냄, 저장
-	err = SendUnclassifiedLinks(notificationAgrees)
+	// 2. 플랫폼별 푸시 제공자 준비
+	senders, err := push.NewSendersFromConfig(setting.Platforms)
 	if err != nil {
-		panic("failed to send or save notifications: " + err.Error())
+		return SendResult{}, fmt.Errorf("푸시 제공자를 준비하는데 실패했습니다: %v", err)
+	}
+
+	// 3. 알림 보냄, 저장
+	result, err := SendUnclassifiedLinks(ctx, runKey, notificationAgrees, senders)
+	if err != nil {
+		return result, fmt.Errorf("failed to send or save notifications: %v", err)
 	}
 	log.Println("END")
+
+	return result, nil
 }
@@ -2,19 +2,22 @@ package notification
 
 import (
 	"context"
+	"errors"
 	"joosum-backend/app/setting"
 	"joosum-backend/pkg/db"
 	"joosum-backend/pkg/util"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"golang.org/x/oauth2"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // 동의 타입
 const (
-	Unread       = "unread"
-	Unclassified = "unclassified"
+	Unread       = setting.NotificationTypeUnread
+	Unclassified = setting.NotificationTypeUnclassified
+	Reminder     = setting.NotificationTypeReminder
 )
 
 type Notification struct {
@@ -25,20 +28,36 @@ type Notification struct {
 	Type           string    `bson:"type"`
 	CreatedAt      time.Time `bson:"created_at"`
 	UserId         string    `bson:"user_id"`
+	// 리마인더 알림의 링크. 링크 개수 알림에는 없음
+	LinkId string `bson:"link_id,omitempty"`
+	// 알림 작업 실행별 사용자 키 (runKey:userId). 같은 실행을 다시 해도 알림이 중복 저장/발송되지 않습니다.
+	IdempotencyKey string `bson:"idempotency_key,omitempty"`
 }
 
-type FcmReq struct {
-	Token        string          `json:"token" bson:"token"`
-	Notification FcmNotification `json:"notification" bson:"notification"`
-}
+var ErrAlreadyNotified = errors.New("notification already saved for this run")
 
-type FcmNotification struct {
-	Body  string `json:"body" bson:"body"`
-	Title string `json:"title" bson:"title"`
-}
+// 토큰별 발송 결과
+const (
+	DeliverySent         = "sent"
+	DeliveryFailed       = "failed"
+	DeliveryTokenRemoved = "token_removed"
+)
 
-type FcmRes struct {
-	Name string `json:"name" bson:"name"`
+// NotificationDelivery 는 디바이스 토큰 하나에 대한 푸시 발송 결과입니다. (notificationDeliveries 컬렉션, 30일 후 삭제)
+type NotificationDelivery struct {
+	DeliveryId       string `bson:"_id"`
+	RunKey           string `bson:"run_key"`
+	UserId           string `bson:"user_id"`
+	NotificationType string `bson:"type"`
+	Token            string `bson:"token"`
+	Platform         string `bson:"platform"`
+	// 발송한 푸시 제공자 (fcm, apns, fake)
+	Provider string `bson:"provider"`
+	Status   string `bson:"status"`
+	// 실패한 경우 제공자의 오류 코드 (FCM: UNREGISTERED, QUOTA_EXCEEDED 등 / APNs: BadDeviceToken, Unregistered 등)
+	Reason    string    `bson:"reason,omitempty"`
+	Error     string    `bson:"error,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
 }
 
 type NotificationResult struct {
@@ -47,39 +66,94 @@ type NotificationResult struct {
 	Err    error
 }
 
-type tokenProvider struct {
-	tokenSource oauth2.TokenSource
+// SendResult 는 알림 작업 한 번의 처리 결과입니다.
+type SendResult struct {
+	// 알림 동의 정보 수
+	Processed int
+	// 푸시를 하나 이상의 디바이스에 보낸 사용자 수
+	Sent int
+	// 조회/저장 실패와 디바이스별 발송 실패 수
+	Failed int
+	// 같은 실행에서 이미 처리해 건너뛴 수 (중단된 실행을 이어서 할 때)
+	Skipped int
+	// 만료/잘못된 토큰이라 디바이스 목록에서 지운 수
+	TokensRemoved int
+	// 사용자의 알림 설정(빈도, 알림 시각, 조용한 시간)에 따라 이번 실행에서 알림을 만들지 않은 수
+	Deferred int
 }
 
-func SaveNotification(userId, title, body, notificationType string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	notification := Notification{
-		NotificationId: util.CreateId("Notification"),
+// SaveNotification 은 알림을 저장합니다. idempotencyKey 로 이미 저장된 알림이 있으면 ErrAlreadyNotified 를 반환합니다.
+func SaveNotification(userId, title, body, notificationType, idempotencyKey string) error {
+	return store.SaveNotification(Notification{
 		Title:          title,
 		Body:           body,
 		Type:           notificationType,
-		CreatedAt:      time.Now(),
 		UserId:         userId,
-	}
+		IdempotencyKey: idempotencyKey,
+	})
+}
+
+func saveNotification(notification Notification) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	notification.NotificationId = util.CreateId("Notification")
+	notification.CreatedAt = time.Now()
 
 	_, err := db.NotificationCollection.InsertOne(ctx, notification)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrAlreadyNotified
+	}
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// getLastNotification 은 사용자가 마지막으로 받은 notificationType 알림입니다. 없으면 nil 입니다.
+// 빈도는 notificationAgrees.last_sent 로 세며, 이 함수는 last_sent 가 없는 사용자에게만 씁니다.
+func getLastNotification(userId, notificationType string) (*Notification, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userId, "type": notificationType}
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	var notification Notification
+	err := db.NotificationCollection.FindOne(ctx, filter, opts).Decode(&notification)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
 func getNotificationAgrees() ([]setting.NotificationAgree, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	cur, err := db.NotificationAgreeCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
 
 	var results []setting.NotificationAgree
-	if err = cur.All(context.TODO(), &results); err != nil {
+	if err = cur.All(ctx, &results); err != nil {
 		return nil, err
 	}
 	return results, nil
 }
+
+func saveDelivery(delivery NotificationDelivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	delivery.DeliveryId = util.CreateId("NotificationDelivery")
+	delivery.CreatedAt = time.Now()
+
+	_, err := db.NotificationDeliveryCollection.InsertOne(ctx, delivery)
+
+	return err
+}
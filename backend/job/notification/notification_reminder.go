@@ -0,0 +1,197 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"joosum-backend/app/link"
+	"joosum-backend/app/setting"
+	"joosum-backend/pkg/i18n"
+	"joosum-backend/pkg/push"
+	"log"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// 한 번 실행에서 보내는 리마인더 수. 남은 리마인더는 다음 실행에서 보냅니다.
+const reminderBatchSize = 500
+
+// reminderData 는 리마인더 알림 템플릿에 넘기는 값입니다.
+type reminderData struct {
+	Title string
+	URL   string
+}
+
+// SendLinkReminders 는 알림 시각이 된 링크 리마인더를 보냅니다. runKey 는 실행의 멱등성 키입니다.
+func SendLinkReminders(ctx context.Context, runKey string) (SendResult, error) {
+
+	// 1. 보낼 리마인더 가져옴
+	reminders, err := link.LinkModel{}.GetDueLinkReminders(time.Now(), reminderBatchSize)
+	if err != nil {
+		return SendResult{}, fmt.Errorf("리마인더 목록을 가져오는데 실패했습니다: %v", err)
+	}
+	log.Printf("%d 개의 리마인더를 가져왔습니다.\n\n", len(reminders))
+
+	// 2. 플랫폼별 푸시 제공자 준비
+	senders, err := push.NewSendersFromConfig(setting.Platforms)
+	if err != nil {
+		return SendResult{}, fmt.Errorf("푸시 제공자를 준비하는데 실패했습니다: %v", err)
+	}
+
+	// 3. 알림 보냄, 저장
+	result, err := SendReminders(ctx, runKey, reminders, senders)
+	if err != nil {
+		return result, fmt.Errorf("failed to send or save reminders: %v", err)
+	}
+	log.Println("END")
+
+	return result, nil
+}
+
+// 링크가 삭제됐거나 예약 후 읽었으면 리마인더 취소, 알림 x 저장 x
+// 알림을 저장(reminder:<리마인더 아이디> 멱등성 키)한 뒤 푸시 동의면 모든 디바이스로 보내고 보냄 처리합니다.
+// 이미 저장된 리마인더 알림이면 (보냄 처리 전에 중단된 실행) 다시 보내지 않고 보냄 처리만 합니다.
+// 리마인더는 사용자가 정한 시각에 보내므로 알림 빈도, 알림 시각, 조용한 시간은 보지 않습니다.
+// 푸시는 링크로 바로 이동할 수 있게 data 에 type, linkId, url 을 넣습니다.
+// 리마인더는 notificationWorkers 개씩 동시에 처리하며, 한 리마인더의 실패(panic 포함)는 다른 리마인더에 영향을 주지 않습니다.
+func SendReminders(ctx context.Context, runKey string, reminders []link.LinkReminder, senders *push.Senders) (SendResult, error) {
+	result := SendResult{Processed: len(reminders)}
+
+	var mu sync.Mutex
+	var successUserIds []string
+	var failUserIds []NotificationResult
+	cancelled := 0
+
+	fail := func(userId, msg string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failUserIds = append(failUserIds, NotificationResult{userId, msg, err})
+	}
+	cancel := func(reminder link.LinkReminder, reason string) {
+		cancelReminder(reminder, reason)
+		mu.Lock()
+		cancelled++
+		mu.Unlock()
+	}
+
+	reminderUserId := func(reminder link.LinkReminder) string { return reminder.UserId }
+
+	interruptErr := forEachUser(ctx, notificationWorkers(), reminders, reminderUserId, func(reminder link.LinkReminder) {
+		userId := reminder.UserId
+
+		l, err := store.GetLink(reminder.LinkId)
+		if err == mongo.ErrNoDocuments {
+			cancel(reminder, link.ReminderCancelDeleted)
+			return
+		}
+		if err != nil {
+			fail(userId, "링크 조회 실패", err)
+			return
+		}
+		if l.LastReadAt.After(reminder.CreatedAt) {
+			cancel(reminder, link.ReminderCancelRead)
+			return
+		}
+
+		agree, err := store.GetNotificationAgree(userId)
+		if err == mongo.ErrNoDocuments {
+			agree, err = &setting.NotificationAgree{UserId: userId}, nil
+		}
+		if err != nil {
+			fail(userId, "알림동의 조회 실패", err)
+			return
+		}
+
+		title := l.Title
+		if title == "" {
+			title = l.URL
+		}
+		message, err := i18n.Render("notification.reminder", agree.Locale, reminderData{Title: title, URL: l.URL})
+		if err != nil {
+			fail(userId, "알림문구 생성 실패", err)
+			return
+		}
+
+		// 알림 저장. 이미 저장한 리마인더면 보냄 처리만 함
+		err = store.SaveNotification(Notification{
+			Title:          message.Title,
+			Body:           message.Body,
+			Type:           Reminder,
+			UserId:         userId,
+			LinkId:         reminder.LinkId,
+			IdempotencyKey: "reminder:" + reminder.ReminderId,
+		})
+		if err == ErrAlreadyNotified {
+			markReminderSent(reminder)
+			mu.Lock()
+			result.Skipped++
+			mu.Unlock()
+			return
+		}
+		if err != nil {
+			fail(userId, "알림저장 실패", err)
+			return
+		}
+
+		// 푸시 동의 일 때 사용자의 모든 디바이스로 보냄
+		if agree.Preference(Reminder).Push {
+			devices, err := activeDevices(*agree)
+			if err != nil {
+				fail(userId, "디바이스 조회 실패", err)
+			} else {
+				sent, tokensRemoved, failures := pushToDevices(ctx, senders, runKey, userId, Reminder, devices, push.Message{
+					Title: message.Title,
+					Body:  message.Body,
+					Data:  map[string]string{"type": Reminder, "linkId": reminder.LinkId, "url": l.URL},
+				})
+				mu.Lock()
+				result.TokensRemoved += tokensRemoved
+				failUserIds = append(failUserIds, failures...)
+				if sent {
+					successUserIds = append(successUserIds, userId)
+				}
+				mu.Unlock()
+			}
+		}
+
+		// 알림함에 저장했으므로 푸시 실패와 관계없이 보냄 처리
+		markReminderSent(reminder)
+	}, func(reminder link.LinkReminder, err error) {
+		fail(reminder.UserId, "리마인더 처리 실패", err)
+	})
+
+	log.Println("\t\t[리마인더 발송 실패 목록]")
+	log.Printf("               UserId                           Message                       Error")
+	for _, failUser := range failUserIds {
+		log.Printf("%s \t %s \t %s", failUser.UserId, failUser.Msg, failUser.Err)
+	}
+	log.Println()
+	log.Printf("successUserIds=%v \n\n %d 개의 리마인더 알림을 보내는데 성공했습니다.\n\n", successUserIds, len(successUserIds))
+	if cancelled > 0 {
+		log.Printf("링크가 삭제됐거나 이미 읽어서 %d 개의 리마인더를 취소했습니다.\n\n", cancelled)
+	}
+	if result.Skipped > 0 {
+		log.Printf("이미 알림을 저장한 %d 개의 리마인더는 보냄 처리만 했습니다. (runKey=%s)\n\n", result.Skipped, runKey)
+	}
+	if result.TokensRemoved > 0 {
+		log.Printf("만료되거나 잘못된 디바이스 토큰 %d 개를 지웠습니다.\n\n", result.TokensRemoved)
+	}
+
+	result.Sent = len(successUserIds)
+	result.Failed = len(failUserIds)
+	return result, interruptErr
+}
+
+func cancelReminder(reminder link.LinkReminder, reason string) {
+	if _, err := store.CancelLinkReminder(reminder.ReminderId, reason); err != nil {
+		log.Printf("[리마인더] 리마인더 취소 실패 (reminderId=%s): %v", reminder.ReminderId, err)
+	}
+}
+
+// markReminderSent 는 리마인더를 보냄 처리합니다. 실패하면 다음 실행에서 다시 처리하며, 멱등성 키로 중복 발송하지 않습니다.
+func markReminderSent(reminder link.LinkReminder) {
+	if _, err := store.MarkLinkReminderSent(reminder.ReminderId); err != nil {
+		log.Printf("[리마인더] 보냄 처리 실패 (reminderId=%s): %v", reminder.ReminderId, err)
+	}
+}
@@ -0,0 +1,125 @@
+package notification
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"joosum-backend/app/link"
+	"joosum-backend/app/setting"
+)
+
+func TestSendReminders(t *testing.T) {
+	s := newMemoryStore()
+	useStore(t, s)
+
+	createdAt := time.Now().Add(-24 * time.Hour)
+	reminders := []link.LinkReminder{
+		{ReminderId: "Reminder-1", LinkId: "Link-1", UserId: "user-1", CreatedAt: createdAt},
+		// 링크가 삭제됨
+		{ReminderId: "Reminder-2", LinkId: "Link-deleted", UserId: "user-1", CreatedAt: createdAt},
+		// 예약 후 읽음
+		{ReminderId: "Reminder-3", LinkId: "Link-3", UserId: "user-2", CreatedAt: createdAt},
+		// 알림 동의 정보가 없어도 보냄. 디바이스 토큰이 만료됨
+		{ReminderId: "Reminder-4", LinkId: "Link-4", UserId: "user-3", CreatedAt: createdAt},
+	}
+	s.links = map[string]*link.Link{
+		"Link-1": {LinkId: "Link-1", Title: "Go 제네릭", URL: "https://go.dev/blog/intro-generics"},
+		"Link-3": {LinkId: "Link-3", Title: "읽은 링크", URL: "https://example.com/read", LastReadAt: time.Now()},
+		"Link-4": {LinkId: "Link-4", URL: "https://example.com/untitled"},
+	}
+	s.agrees = map[string]*setting.NotificationAgree{
+		"user-1": {UserId: "user-1"},
+		"user-2": {UserId: "user-2"},
+	}
+	s.devices = map[string][]setting.Device{
+		"user-1": {{UserId: "user-1", Token: "token-1", Platform: setting.PlatformIOS}},
+		"user-3": {{UserId: "user-3", Token: "expired-3", Platform: setting.PlatformIOS}},
+	}
+
+	senders, sender := newTestSenders()
+
+	result, err := SendReminders(context.Background(), "reminder:2026-10-19T10:00", reminders, senders)
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+
+	if want := []string{"user-1", "user-3"}; !equalStrings(s.savedNotifications(Reminder), want) {
+		t.Errorf("저장한 알림 = %v, want %v", s.savedNotifications(Reminder), want)
+	}
+	if want := []string{"token-1"}; !equalStrings(sentTokens(sender), want) {
+		t.Errorf("보낸 푸시 = %v, want %v", sentTokens(sender), want)
+	}
+	if msg := sender.Sent()[0]; msg.Data["type"] != Reminder || msg.Data["linkId"] != "Link-1" || msg.Data["url"] != "https://go.dev/blog/intro-generics" {
+		t.Errorf("푸시 data = %v", msg.Data)
+	}
+	if s.cancelled["Reminder-2"] != link.ReminderCancelDeleted || s.cancelled["Reminder-3"] != link.ReminderCancelRead {
+		t.Errorf("취소한 리마인더 = %v", s.cancelled)
+	}
+	if len(s.devices["user-3"]) != 0 {
+		t.Errorf("만료된 토큰을 지우지 않음: %+v", s.devices["user-3"])
+	}
+
+	sentReminders := append([]string(nil), s.sentReminders...)
+	sort.Strings(sentReminders)
+	if want := []string{"Reminder-1", "Reminder-4"}; !equalStrings(sentReminders, want) {
+		t.Errorf("보냄 처리한 리마인더 = %v, want %v", sentReminders, want)
+	}
+
+	if want := (SendResult{Processed: 4, Sent: 1, Failed: 1, TokensRemoved: 1}); result != want {
+		t.Errorf("result = %+v, want %+v", result, want)
+	}
+
+	// 보냄 처리 전에 중단된 실행을 다시 하면 알림을 다시 보내지 않고 보냄 처리만 함
+	result, err = SendReminders(context.Background(), "reminder:2026-10-19T10:05", reminders[:1], senders)
+	if err != nil {
+		t.Fatalf("다시 실행 err = %v", err)
+	}
+	if got := len(sender.Sent()); got != 1 {
+		t.Errorf("다시 실행 후 보낸 푸시 수 = %d, want 1", got)
+	}
+	if want := (SendResult{Processed: 1, Skipped: 1}); result != want {
+		t.Errorf("다시 실행 result = %+v, want %+v", result, want)
+	}
+}
+
+// panicStore 는 panicLinkId 링크를 조회하면 panic 합니다.
+type panicStore struct {
+	*memoryStore
+	panicLinkId string
+}
+
+func (s panicStore) GetLink(linkId string) (*link.Link, error) {
+	if linkId == s.panicLinkId {
+		panic("unexpected")
+	}
+	return s.memoryStore.GetLink(linkId)
+}
+
+func TestSendRemindersIsolatesPanic(t *testing.T) {
+	s := newMemoryStore()
+	useStore(t, panicStore{memoryStore: s, panicLinkId: "Link-panic"})
+
+	reminders := []link.LinkReminder{
+		{ReminderId: "Reminder-1", LinkId: "Link-panic", UserId: "user-1"},
+		{ReminderId: "Reminder-2", LinkId: "Link-2", UserId: "user-2"},
+	}
+	s.links = map[string]*link.Link{"Link-2": {LinkId: "Link-2", Title: "링크", URL: "https://example.com/2"}}
+	s.devices = map[string][]setting.Device{
+		"user-2": {{UserId: "user-2", Token: "token-2", Platform: setting.PlatformAndroid}},
+	}
+
+	senders, sender := newTestSenders()
+
+	result, err := SendReminders(context.Background(), "reminder:2026-10-19T10:00", reminders, senders)
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if want := []string{"token-2"}; !equalStrings(sentTokens(sender), want) {
+		t.Errorf("보낸 푸시 = %v, want %v", sentTokens(sender), want)
+	}
+	if want := (SendResult{Processed: 2, Sent: 1, Failed: 1}); result != want {
+		t.Errorf("result = %+v, want %+v", result, want)
+	}
+}
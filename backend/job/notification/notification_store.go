@@ -0,0 +1,92 @@
+package notification
+
+import (
+	"time"
+
+	"joosum-backend/app/link"
+	"joosum-backend/app/setting"
+)
+
+// notificationStore 는 알림 작업이 읽고 쓰는 데이터입니다. (링크 개수, 알림함, 알림 설정, 디바이스, 리마인더, 발송 결과)
+// 운영에서는 mongoStore 를 쓰고, 테스트에서는 메모리 저장소로 바꿔 알림 작업을 처음부터 끝까지 실행합니다.
+type notificationStore interface {
+	GetUserUnreadLinkCount(userId string) (int64, error)
+	GetDefaultLinkBookId(userId string) (string, error)
+	GetLinkBookLinkCount(linkBookId string) (int64, error)
+
+	// idempotencyKey 로 이미 저장된 알림이 있으면 ErrAlreadyNotified 를 반환합니다.
+	SaveNotification(notification Notification) error
+	// 사용자가 마지막으로 받은 notificationType 알림. 없으면 nil 입니다.
+	GetLastNotification(userId, notificationType string) (*Notification, error)
+	SaveDelivery(delivery NotificationDelivery) error
+
+	GetNotificationAgree(userId string) (*setting.NotificationAgree, error)
+	UpdateLastSent(userId, notificationType string, sent setting.NotificationSent) error
+	GetActiveDevices(userId string, since time.Time) ([]setting.Device, error)
+	DeleteDeviceToken(userId, token string) (bool, error)
+
+	GetLink(linkId string) (*link.Link, error)
+	CancelLinkReminder(reminderId, reason string) (bool, error)
+	MarkLinkReminderSent(reminderId string) (bool, error)
+}
+
+var store notificationStore = mongoStore{}
+
+// mongoStore 는 각 모델의 MongoDB 컬렉션을 사용합니다.
+type mongoStore struct{}
+
+func (mongoStore) GetUserUnreadLinkCount(userId string) (int64, error) {
+	return link.LinkModel{}.GetUserUnreadLinkCount(userId)
+}
+
+func (mongoStore) GetDefaultLinkBookId(userId string) (string, error) {
+	linkBook, err := link.LinkBookModel{}.GetDefaultLinkBook(userId)
+	if err != nil {
+		return "", err
+	}
+	return linkBook.LinkBookId, nil
+}
+
+func (mongoStore) GetLinkBookLinkCount(linkBookId string) (int64, error) {
+	return link.LinkModel{}.GetLinkBookLinkCount(linkBookId)
+}
+
+func (mongoStore) SaveNotification(notification Notification) error {
+	return saveNotification(notification)
+}
+
+func (mongoStore) GetLastNotification(userId, notificationType string) (*Notification, error) {
+	return getLastNotification(userId, notificationType)
+}
+
+func (mongoStore) SaveDelivery(delivery NotificationDelivery) error {
+	return saveDelivery(delivery)
+}
+
+func (mongoStore) GetNotificationAgree(userId string) (*setting.NotificationAgree, error) {
+	return setting.SettingModel{}.GetNotificationAgree(userId)
+}
+
+func (mongoStore) UpdateLastSent(userId, notificationType string, sent setting.NotificationSent) error {
+	return setting.SettingModel{}.UpdateLastSent(userId, notificationType, sent)
+}
+
+func (mongoStore) GetActiveDevices(userId string, since time.Time) ([]setting.Device, error) {
+	return setting.DeviceModel{}.GetActiveDevices(userId, since)
+}
+
+func (mongoStore) DeleteDeviceToken(userId, token string) (bool, error) {
+	return setting.SettingModel{}.DeleteDeviceToken(userId, token)
+}
+
+func (mongoStore) GetLink(linkId string) (*link.Link, error) {
+	return link.LinkModel{}.GetOneLinkByLinkId(linkId)
+}
+
+func (mongoStore) CancelLinkReminder(reminderId, reason string) (bool, error) {
+	return link.LinkModel{}.CancelLinkReminder(reminderId, reason)
+}
+
+func (mongoStore) MarkLinkReminderSent(reminderId string) (bool, error) {
+	return link.LinkModel{}.MarkLinkReminderSent(reminderId)
+}
@@ -3,214 +3,322 @@ package notification
 import (
 	"context"
 	"errors"
-	"fmt"
-	"io/ioutil"
-	"joosum-backend/app/link"
 	"joosum-backend/app/setting"
-	"joosum-backend/pkg/config"
+	"joosum-backend/pkg/i18n"
+	"joosum-backend/pkg/push"
 	"log"
-	. "strconv"
 	"strings"
-
-	"github.com/go-resty/resty/v2"
-	"golang.org/x/oauth2/google"
+	"sync"
+	"time"
 )
 
-// count == 0 알림 x, 저장 x
-// device id == null 알림 x 저장 o
-// 동의여부 == true 면 알림 o 저장 o, false 면 알림 x 저장 o
-func SendUnreadLinks(notificationAgrees []setting.NotificationAgree) error {
-	linkModel := link.LinkModel{}
-
-	projectId := config.GetEnvConfig("projectId")
-	googleToken, err := getAccesstoken()
-	if err != nil {
-		return err
-	}
-	var successUserIds []string
-	var failUserIds []NotificationResult
-
-	client := resty.New()
-	client.SetAuthToken(googleToken)
-	for _, notificationAgree := range notificationAgrees {
-		title := "읽지 않은 링크가 n건 있어요."
-		body := "저장해 둔 링크를 확인해보세요!"
-
-		userId := notificationAgree.UserId
-		deviceToken := notificationAgree.DeviceId
+// linkNotification 은 링크 개수로 보내는 알림 한 종류입니다. (읽지 않은 링크, 분류되지 않은 링크)
+type linkNotification struct {
+	notificationType string
+	// 로그에 쓰는 이름
+	label string
+	// 제목, 본문 템플릿 이름 (pkg/i18n/locales). 템플릿에는 링크 개수(Count)를 넘깁니다.
+	template string
+	// 알림에 쓸 링크 개수. 실패하면 실패 사유 메시지와 오류를 반환합니다.
+	count func(userId string) (int64, string, error)
+}
 
+var unreadLinkNotification = linkNotification{
+	notificationType: Unread,
+	label:            "읽지않은 링크",
+	template:         "notification.unread",
+	count: func(userId string) (int64, string, error) {
 		// 읽지않은 링크 갯수 세기
-		unreadLinkCnt, err := linkModel.GetUserUnreadLinkCount(userId)
+		cnt, err := store.GetUserUnreadLinkCount(userId)
 		if err != nil {
-			failUserIds = append(failUserIds, NotificationResult{userId, "링크갯수 조회 실패", err})
-			continue
-		}
-
-		// count == 0 이면 패스
-		if unreadLinkCnt == 0 {
-			continue
+			return 0, "링크갯수 조회 실패", err
 		}
+		return cnt, "", nil
+	},
+}
 
-		title = strings.Replace(title, "n", FormatInt(unreadLinkCnt, 10), 1)
-
-		// device id 가 null 이 아니고, 알림 동의 일 때
-		if deviceToken != nil && notificationAgree.IsReadAgree {
-
-			// Firebase 로 알림 보내기
-			result := sendNotification(client, deviceToken, title, body, projectId)
-
-			if result.IsSuccess() {
-				successUserIds = append(successUserIds, userId)
-
-			} else {
-				failUserIds = append(failUserIds, NotificationResult{userId, "알림발송 실패", errors.New("")})
-			}
+var unclassifiedLinkNotification = linkNotification{
+	notificationType: Unclassified,
+	label:            "분류되지 않은 링크",
+	template:         "notification.unclassified",
+	count: func(userId string) (int64, string, error) {
+		// 분류되지 않은 링크 갯수 세기
+		defaultLinkBookId, err := store.GetDefaultLinkBookId(userId)
+		if err != nil {
+			return 0, "기본폴더 조회 실패", err
 		}
 
-		// 알림 저장
-		err = SaveNotification(userId, title, body, Unread)
+		cnt, err := store.GetLinkBookLinkCount(defaultLinkBookId)
 		if err != nil {
-			failUserIds = append(failUserIds, NotificationResult{userId, "알림저장 실패", err})
+			return 0, "링크갯수 조회 실패", err
 		}
-	}
+		return cnt, "", nil
+	},
+}
 
-	log.Println("\t\t[알림발송 실패 목록]\n")
-	log.Printf("               UserId                           Message                       Error")
-	for _, failUser := range failUserIds {
-		log.Printf("%s \t %s \t %s", failUser.UserId, failUser.Msg, failUser.Err)
-	}
-	log.Println()
-	log.Printf("successUserIds=%v \n\n %d 개의 '읽지않은 링크' 알림을 보내는데 성공했습니다.\n\n", successUserIds, len(successUserIds))
+// linkCountData 는 링크 개수 알림 템플릿에 넘기는 값입니다.
+type linkCountData struct {
+	Count int64
+}
 
-	return nil
+// SendUnreadLinks 는 읽지 않은 링크 알림을 저장하고 senders 로 보냅니다. (테스트에서는 push.FakeSender 를 넘깁니다)
+func SendUnreadLinks(ctx context.Context, runKey string, notificationAgrees []setting.NotificationAgree, senders *push.Senders) (SendResult, error) {
+	return sendLinkNotifications(ctx, runKey, notificationAgrees, unreadLinkNotification, senders)
 }
 
-func SendUnclassifiedLinks(notificationAgrees []setting.NotificationAgree) error {
-	linkModel := link.LinkModel{}
-	linkBookModel := link.LinkBookModel{}
+// SendUnclassifiedLinks 는 분류되지 않은 링크 알림을 저장하고 senders 로 보냅니다.
+func SendUnclassifiedLinks(ctx context.Context, runKey string, notificationAgrees []setting.NotificationAgree, senders *push.Senders) (SendResult, error) {
+	return sendLinkNotifications(ctx, runKey, notificationAgrees, unclassifiedLinkNotification, senders)
+}
 
-	projectId := config.GetEnvConfig("projectId")
-	googleToken, err := getAccesstoken()
-	if err != nil {
-		return err
-	}
+// 빈도 off, 알림 시각 전/조용한 시간, 빈도상 아직 보낼 때가 아님 (사용자 시간대 기준) 알림 x, 저장 x
+// count == 0 알림 x, 저장 x
+// 디바이스가 없으면 알림 x 저장 o
+// 푸시 동의 == true 면 모든 디바이스로 알림 o 저장 o, false 면 알림 x 저장 o
+// 알림을 먼저 저장(runKey:userId 멱등성 키)한 뒤 발송하므로, 같은 runKey 로 다시 실행하면 이미 처리한 사용자는 건너뜁니다.
+// (저장과 발송 사이에 프로세스가 죽으면 그 사용자에게는 푸시가 가지 않지만, 중복 발송은 하지 않습니다.)
+// 알림을 만들면 notificationAgrees.last_sent.<종류> 에 시각과 runKey 를 남겨, 사용자가 알림함을 비워도 빈도를 지킵니다.
+// 사용자는 notificationWorkers 개씩 동시에 처리하며, 한 사용자의 실패는 다른 사용자에게 영향을 주지 않습니다.
+// 디바이스마다 플랫폼에 맞는 푸시 제공자(senders.ForPlatform)로 보냅니다.
+func sendLinkNotifications(ctx context.Context, runKey string, notificationAgrees []setting.NotificationAgree, n linkNotification, senders *push.Senders) (SendResult, error) {
+	result := SendResult{Processed: len(notificationAgrees)}
+	now := time.Now()
+
+	var mu sync.Mutex
 	var successUserIds []string
 	var failUserIds []NotificationResult
 
-	client := resty.New()
-	client.SetAuthToken(googleToken)
-	for _, notificationAgree := range notificationAgrees {
-		title := "분류되지 않은 링크가 n건 있어요."
-		body := "폴더를 만들어서 정리해보세요!"
+	fail := func(userId, msg string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failUserIds = append(failUserIds, NotificationResult{userId, msg, err})
+	}
 
+	interruptErr := forEachAgree(ctx, notificationWorkers(), notificationAgrees, func(notificationAgree setting.NotificationAgree) {
 		userId := notificationAgree.UserId
-		deviceToken := notificationAgree.DeviceId
+		idempotencyKey := runKey + ":" + userId
 
-		// 분류되지 않은 링크 갯수 세기
-		defaultLinkBook, err := linkBookModel.GetDefaultLinkBook(userId)
+		// 사용자 알림 설정 확인
+		if notificationAgree.Preference(n.notificationType).Frequency == setting.FrequencyOff {
+			return
+		}
+
+		last, err := lastSent(notificationAgree, n.notificationType)
 		if err != nil {
-			failUserIds = append(failUserIds, NotificationResult{userId, "기본폴더 조회 실패", err})
-			continue
+			fail(userId, "마지막 알림 조회 실패", err)
+			return
+		}
+		if last.RunKey == runKey {
+			mu.Lock()
+			result.Skipped++
+			mu.Unlock()
+			return
+		}
+
+		if !notificationAgree.IsDeliveryTime(now) || !notificationAgree.IsDue(n.notificationType, last.At, now) {
+			mu.Lock()
+			result.Deferred++
+			mu.Unlock()
+			return
 		}
 
-		unclassifyCnt, err := linkModel.GetLinkBookLinkCount(defaultLinkBook.LinkBookId)
+		cnt, failMsg, err := n.count(userId)
 		if err != nil {
-			failUserIds = append(failUserIds, NotificationResult{userId, "링크갯수 조회 실패", err})
-			continue
+			fail(userId, failMsg, err)
+			return
 		}
 
 		// count == 0 이면 패스
-		if unclassifyCnt == 0 {
-			continue
+		if cnt == 0 {
+			return
 		}
 
-		title = strings.Replace(title, "n", FormatInt(unclassifyCnt, 10), 1)
+		// 사용자 언어로 알림 문구 만들기. 저장하는 알림과 푸시에 같은 문구를 씀
+		message, err := i18n.Render(n.template, notificationAgree.Locale, linkCountData{Count: cnt})
+		if err != nil {
+			fail(userId, "알림문구 생성 실패", err)
+			return
+		}
 
-		// device id 가 null 이 아니고, 알림 동의 일 때
-		if deviceToken != nil && notificationAgree.IsClassifyAgree {
+		// 알림 저장. 이미 이 실행에서 처리한 사용자면 건너뜀
+		err = SaveNotification(userId, message.Title, message.Body, n.notificationType, idempotencyKey)
+		if err != nil && err != ErrAlreadyNotified {
+			fail(userId, "알림저장 실패", err)
+			return
+		}
 
-			// Firebase 로 알림 보내기
-			result := sendNotification(client, deviceToken, title, body, projectId)
+		// 빈도를 셀 수 있게 마지막으로 알림을 만든 기록을 남김
+		record := setting.NotificationSent{At: now, RunKey: runKey}
+		if updateErr := store.UpdateLastSent(userId, n.notificationType, record); updateErr != nil {
+			fail(userId, "마지막 알림 기록 실패", updateErr)
+		}
 
-			if result.IsSuccess() {
-				successUserIds = append(successUserIds, userId)
+		if err == ErrAlreadyNotified {
+			mu.Lock()
+			result.Skipped++
+			mu.Unlock()
+			return
+		}
 
-			} else {
-				failUserIds = append(failUserIds, NotificationResult{userId, "알림발송 실패", errors.New("")})
-			}
+		// 푸시 동의 일 때 사용자의 모든 디바이스로 보냄
+		if !notificationAgree.Preference(n.notificationType).Push {
+			return
 		}
 
-		// 알림 저장
-		err = SaveNotification(userId, title, body, Unclassified)
+		devices, err := activeDevices(notificationAgree)
 		if err != nil {
-			failUserIds = append(failUserIds, NotificationResult{userId, "알림저장 실패", err})
+			fail(userId, "디바이스 조회 실패", err)
+			return
+		}
+
+		sent, tokensRemoved, failures := pushToDevices(ctx, senders, runKey, userId, n.notificationType, devices, push.Message{
+			Title: message.Title,
+			Body:  message.Body,
+			Data:  map[string]string{"type": n.notificationType},
+		})
+		if tokensRemoved > 0 || len(failures) > 0 {
+			mu.Lock()
+			result.TokensRemoved += tokensRemoved
+			failUserIds = append(failUserIds, failures...)
+			mu.Unlock()
 		}
-	}
 
-	log.Println("\t\t[알림발송 실패 목록]\n")
+		if sent {
+			mu.Lock()
+			successUserIds = append(successUserIds, userId)
+			mu.Unlock()
+		}
+	}, func(agree setting.NotificationAgree, err error) {
+		fail(agree.UserId, "알림처리 실패", err)
+	})
+
+	log.Println("\t\t[알림발송 실패 목록]")
 	log.Printf("               UserId                           Message                       Error")
 	for _, failUser := range failUserIds {
 		log.Printf("%s \t %s \t %s", failUser.UserId, failUser.Msg, failUser.Err)
 	}
 	log.Println()
-	log.Printf("successUserIds=%v \n\n %d 개의 '분류되지 않은 링크' 알림을 보내는데 성공했습니다.\n\n", successUserIds, len(successUserIds))
+	log.Printf("successUserIds=%v \n\n %d 개의 '%s' 알림을 보내는데 성공했습니다.\n\n", successUserIds, len(successUserIds), n.label)
+	if result.Skipped > 0 {
+		log.Printf("이미 처리한 %d 명은 건너뛰었습니다. (runKey=%s)\n\n", result.Skipped, runKey)
+	}
+	if result.Deferred > 0 {
+		log.Printf("알림 설정(빈도, 알림 시각, 조용한 시간)에 따라 %d 명은 다음 실행으로 미뤘습니다.\n\n", result.Deferred)
+	}
+	if result.TokensRemoved > 0 {
+		log.Printf("만료되거나 잘못된 디바이스 토큰 %d 개를 지웠습니다.\n\n", result.TokensRemoved)
+	}
 
-	return nil
+	result.Sent = len(successUserIds)
+	result.Failed = len(failUserIds)
+	return result, interruptErr
 }
 
-func getAccesstoken() (string, error) {
-	tokenProvider, err := newTokenProvider("fireBaseKey.json")
-	if err != nil {
-		return "", fmt.Errorf("Failed to get Token provider: %v", err)
+// lastSent 는 사용자가 마지막으로 받은 notificationType 알림의 기록입니다. 받은 적이 없으면 zero 입니다.
+// last_sent 를 저장하기 전에 알림을 받은 사용자만 알림함의 마지막 알림으로 대신합니다. (처음 한 번만)
+func lastSent(agree setting.NotificationAgree, notificationType string) (setting.NotificationSent, error) {
+	if sent, ok := agree.LastSent[notificationType]; ok {
+		return sent, nil
 	}
-	token, err := tokenProvider.token()
-	if err != nil {
-		return "", fmt.Errorf("Failed to get Token: %v", err)
+
+	last, err := store.GetLastNotification(agree.UserId, notificationType)
+	if err != nil || last == nil {
+		return setting.NotificationSent{}, err
 	}
 
-	return token, nil
+	runKey, _, _ := strings.Cut(last.IdempotencyKey, ":"+agree.UserId)
+	return setting.NotificationSent{At: last.CreatedAt, RunKey: runKey}, nil
 }
 
-// newTokenProvider function to get token for fcm-send
-func newTokenProvider(credentialsLocation string) (*tokenProvider, error) {
-	jsonKey, err := ioutil.ReadFile(credentialsLocation)
-	if err != nil {
-		return nil, errors.New("fcm: failed to read credentials file at: " + credentialsLocation)
-	}
-	cfg, err := google.JWTConfigFromJSON(jsonKey, firebaseScope)
-	if err != nil {
-		return nil, errors.New("fcm: failed to get JWT config for the firebase.messaging scope: " + err.Error())
+// pushToDevices 는 사용자의 디바이스마다 플랫폼에 맞는 푸시 제공자(FCM, APNs)로 message 를 보내고 디바이스별 결과를 기록합니다.
+// 하나 이상의 디바이스로 보냈는지, 지운 토큰 수, 디바이스별 실패를 반환합니다.
+func pushToDevices(ctx context.Context, senders *push.Senders, runKey, userId, notificationType string, devices []setting.Device, message push.Message) (bool, int, []NotificationResult) {
+	sent := false
+	tokensRemoved := 0
+	var failures []NotificationResult
+
+	for _, device := range devices {
+		sender := senders.ForPlatform(device.Platform)
+		delivery := NotificationDelivery{
+			RunKey:           runKey,
+			UserId:           userId,
+			NotificationType: notificationType,
+			Token:            device.Token,
+			Platform:         device.Platform,
+			Provider:         sender.Name(),
+			Status:           DeliverySent,
+		}
+
+		message.Token = device.Token
+		err := sender.Send(ctx, message)
+		if err != nil {
+			if deliveryFailed(delivery, err) == DeliveryTokenRemoved {
+				tokensRemoved++
+			}
+			failures = append(failures, NotificationResult{userId, "알림발송 실패 (" + device.Platform + ")", err})
+			continue
+		}
+
+		recordDelivery(delivery)
+		sent = true
 	}
-	ts := cfg.TokenSource(context.Background())
-	return &tokenProvider{
-		tokenSource: ts,
-	}, nil
+
+	return sent, tokensRemoved, failures
 }
 
-func (src *tokenProvider) token() (string, error) {
-	token, err := src.tokenSource.Token()
+// activeDevices 는 푸시를 보낼 사용자의 디바이스입니다. (최근 DeviceActiveDays 일 안에 등록/갱신한 디바이스)
+// 디바이스 목록으로 옮기기 전의 앱이 notificationAgrees.device_id 에만 저장한 토큰도 함께 보냅니다.
+func activeDevices(agree setting.NotificationAgree) ([]setting.Device, error) {
+	since := time.Now().AddDate(0, 0, -setting.DeviceActiveDays)
+
+	devices, err := store.GetActiveDevices(agree.UserId, since)
 	if err != nil {
-		return "", errors.New("fcm: failed to generate Bearer token")
+		return nil, err
+	}
+
+	if agree.DeviceId != nil && *agree.DeviceId != "" {
+		for _, device := range devices {
+			if device.Token == *agree.DeviceId {
+				return devices, nil
+			}
+		}
+		devices = append(devices, setting.Device{UserId: agree.UserId, Token: *agree.DeviceId, Platform: setting.PlatformUnknown})
 	}
-	return token.AccessToken, nil
+
+	return devices, nil
 }
 
-func sendNotification(client *resty.Client, deviceToken *string, title, body, projectId string) *resty.Response {
-	msg := FcmReq{
-		Token: *deviceToken,
-		Notification: FcmNotification{
-			Title: title,
-			Body:  body,
-		},
+// deliveryFailed 는 발송 실패를 기록하고, 다시 쓸 수 없는 토큰(FCM UNREGISTERED, APNs Unregistered 등)이면 디바이스 목록에서 지웁니다.
+// 기록한 발송 상태를 반환합니다.
+func deliveryFailed(delivery NotificationDelivery, err error) string {
+	delivery.Status = DeliveryFailed
+	delivery.Error = err.Error()
+
+	var pushErr *push.Error
+	if errors.As(err, &pushErr) {
+		delivery.Reason = pushErr.Reason
+
+		if pushErr.ConfigError {
+			log.Printf("[알림] 푸시 설정 오류 (%s %s): %s", pushErr.Provider, pushErr.Reason, pushErr.Message)
+		}
+		if pushErr.InvalidToken {
+			removed, removeErr := store.DeleteDeviceToken(delivery.UserId, delivery.Token)
+			if removeErr != nil {
+				log.Printf("[알림] 디바이스 토큰 삭제 실패 (userId=%s): %v", delivery.UserId, removeErr)
+			} else if removed {
+				delivery.Status = DeliveryTokenRemoved
+			}
+		}
 	}
 
-	var res FcmRes
-	var authErr resty.Request
-	result, _ := client.R().
-		SetBody(map[string]FcmReq{"message": msg}).
-		SetResult(&res).
-		SetError(&authErr). // or SetError(AuthError{}).
-		Post("https://fcm.googleapis.com/v1/projects/" + projectId + "/messages:send")
+	recordDelivery(delivery)
 
-	return result
+	return delivery.Status
+}
+
+// recordDelivery 는 발송 결과를 저장합니다. 저장에 실패해도 발송은 계속합니다.
+func recordDelivery(delivery NotificationDelivery) {
+	if err := store.SaveDelivery(delivery); err != nil {
+		log.Printf("[알림] 발송 결과 저장 실패 (userId=%s): %v", delivery.UserId, err)
+	}
 }
@@ -0,0 +1,306 @@
+package notification
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"joosum-backend/app/link"
+	"joosum-backend/app/setting"
+	"joosum-backend/pkg/push"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memoryStore 는 알림 작업 테스트에 쓰는 메모리 저장소입니다.
+type memoryStore struct {
+	mu sync.Mutex
+
+	unreadCounts       map[string]int64
+	defaultLinkBookIds map[string]string
+	linkBookCounts     map[string]int64
+	agrees             map[string]*setting.NotificationAgree
+	devices            map[string][]setting.Device
+	links              map[string]*link.Link
+
+	notifications []Notification
+	deliveries    []NotificationDelivery
+	lastSent      map[string]setting.NotificationSent
+	cancelled     map[string]string
+	sentReminders []string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		unreadCounts:       map[string]int64{},
+		defaultLinkBookIds: map[string]string{},
+		linkBookCounts:     map[string]int64{},
+		agrees:             map[string]*setting.NotificationAgree{},
+		devices:            map[string][]setting.Device{},
+		links:              map[string]*link.Link{},
+		lastSent:           map[string]setting.NotificationSent{},
+		cancelled:          map[string]string{},
+	}
+}
+
+// useStore 는 테스트 동안 알림 작업이 s 를 쓰게 합니다.
+func useStore(t *testing.T, s notificationStore) {
+	prev := store
+	store = s
+	t.Cleanup(func() { store = prev })
+}
+
+func (s *memoryStore) GetUserUnreadLinkCount(userId string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.unreadCounts[userId], nil
+}
+
+func (s *memoryStore) GetDefaultLinkBookId(userId string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.defaultLinkBookIds[userId]
+	if !ok {
+		return "", mongo.ErrNoDocuments
+	}
+	return id, nil
+}
+
+func (s *memoryStore) GetLinkBookLinkCount(linkBookId string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.linkBookCounts[linkBookId], nil
+}
+
+func (s *memoryStore) SaveNotification(notification Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, saved := range s.notifications {
+		if notification.IdempotencyKey != "" && saved.IdempotencyKey == notification.IdempotencyKey {
+			return ErrAlreadyNotified
+		}
+	}
+	notification.CreatedAt = time.Now()
+	s.notifications = append(s.notifications, notification)
+	return nil
+}
+
+func (s *memoryStore) GetLastNotification(userId, notificationType string) (*Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.notifications) - 1; i >= 0; i-- {
+		if n := s.notifications[i]; n.UserId == userId && n.Type == notificationType {
+			return &n, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *memoryStore) SaveDelivery(delivery NotificationDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries = append(s.deliveries, delivery)
+	return nil
+}
+
+func (s *memoryStore) GetNotificationAgree(userId string) (*setting.NotificationAgree, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	agree, ok := s.agrees[userId]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return agree, nil
+}
+
+func (s *memoryStore) UpdateLastSent(userId, notificationType string, sent setting.NotificationSent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastSent[userId+":"+notificationType] = sent
+	return nil
+}
+
+func (s *memoryStore) GetActiveDevices(userId string, since time.Time) ([]setting.Device, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]setting.Device(nil), s.devices[userId]...), nil
+}
+
+func (s *memoryStore) DeleteDeviceToken(userId, token string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	devices := s.devices[userId]
+	for i, device := range devices {
+		if device.Token == token {
+			s.devices[userId] = append(devices[:i:i], devices[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *memoryStore) GetLink(linkId string) (*link.Link, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.links[linkId]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return l, nil
+}
+
+func (s *memoryStore) CancelLinkReminder(reminderId, reason string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cancelled[reminderId] = reason
+	return true, nil
+}
+
+func (s *memoryStore) MarkLinkReminderSent(reminderId string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sentReminders = append(s.sentReminders, reminderId)
+	return true, nil
+}
+
+func (s *memoryStore) savedNotifications(notificationType string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var userIds []string
+	for _, n := range s.notifications {
+		if n.Type == notificationType {
+			userIds = append(userIds, n.UserId)
+		}
+	}
+	sort.Strings(userIds)
+	return userIds
+}
+
+// newTestSenders 는 모든 플랫폼을 sender 로 보내고, "expired-" 로 시작하는 토큰은 만료된 토큰으로 실패합니다.
+func newTestSenders() (*push.Senders, *push.FakeSender) {
+	sender := push.NewFakeSender()
+	sender.Fail = func(msg push.Message) error {
+		if len(msg.Token) > len("expired-") && msg.Token[:len("expired-")] == "expired-" {
+			return &push.Error{Provider: push.ProviderFake, Reason: push.FcmErrUnregistered, InvalidToken: true}
+		}
+		return nil
+	}
+	return push.NewSenders(nil, sender), sender
+}
+
+func sentTokens(sender *push.FakeSender) []string {
+	var tokens []string
+	for _, msg := range sender.Sent() {
+		tokens = append(tokens, msg.Token)
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+func TestSendUnreadLinks(t *testing.T) {
+	s := newMemoryStore()
+	useStore(t, s)
+
+	// 알림 시각을 0시로 두어 테스트를 실행하는 시각과 관계없이 보냄
+	anyHour := 0
+	agrees := []setting.NotificationAgree{
+		// 푸시 동의, 디바이스 두 개 중 하나는 만료된 토큰
+		{UserId: "user-1", IsReadAgree: true, NotifyHour: &anyHour},
+		// 읽지 않은 링크가 없음
+		{UserId: "user-2", IsReadAgree: true, NotifyHour: &anyHour},
+		// 푸시 미동의. 알림함에만 저장
+		{UserId: "user-3", IsReadAgree: false, NotifyHour: &anyHour},
+		// 알림 끔
+		{UserId: "user-4", IsReadAgree: true, NotifyHour: &anyHour, Preferences: map[string]setting.NotificationPreference{
+			Unread: {Push: true, Frequency: setting.FrequencyOff},
+		}},
+	}
+	s.unreadCounts = map[string]int64{"user-1": 3, "user-2": 0, "user-3": 5, "user-4": 2}
+	s.devices = map[string][]setting.Device{
+		"user-1": {
+			{UserId: "user-1", Token: "token-1", Platform: setting.PlatformIOS},
+			{UserId: "user-1", Token: "expired-1", Platform: setting.PlatformAndroid},
+		},
+		"user-3": {{UserId: "user-3", Token: "token-3", Platform: setting.PlatformIOS}},
+		"user-4": {{UserId: "user-4", Token: "token-4", Platform: setting.PlatformIOS}},
+	}
+
+	senders, sender := newTestSenders()
+
+	result, err := SendUnreadLinks(context.Background(), "unread:2026-10-19", agrees, senders)
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+
+	if want := []string{"user-1", "user-3"}; !equalStrings(s.savedNotifications(Unread), want) {
+		t.Errorf("저장한 알림 = %v, want %v", s.savedNotifications(Unread), want)
+	}
+	if want := []string{"token-1"}; !equalStrings(sentTokens(sender), want) {
+		t.Errorf("보낸 푸시 = %v, want %v", sentTokens(sender), want)
+	}
+	if msg := sender.Sent()[0]; msg.Data["type"] != Unread || msg.Title == "" || msg.Body == "" {
+		t.Errorf("푸시 메시지 = %+v", msg)
+	}
+	if got := s.devices["user-1"]; len(got) != 1 || got[0].Token != "token-1" {
+		t.Errorf("만료된 토큰을 지우지 않음: %+v", got)
+	}
+	if sent, ok := s.lastSent["user-3:"+Unread]; !ok || sent.RunKey != "unread:2026-10-19" {
+		t.Errorf("마지막 알림 기록 = %+v, want runKey 기록", sent)
+	}
+
+	want := SendResult{Processed: 4, Sent: 1, Failed: 1, TokensRemoved: 1}
+	if result != want {
+		t.Errorf("result = %+v, want %+v", result, want)
+	}
+
+	// 같은 runKey 로 다시 실행하면 이미 처리한 사용자는 건너뜀
+	result, err = SendUnreadLinks(context.Background(), "unread:2026-10-19", agrees, senders)
+	if err != nil {
+		t.Fatalf("다시 실행 err = %v", err)
+	}
+	if got := len(s.savedNotifications(Unread)); got != 2 {
+		t.Errorf("다시 실행 후 저장한 알림 수 = %d, want 2", got)
+	}
+	if got := len(sender.Sent()); got != 1 {
+		t.Errorf("다시 실행 후 보낸 푸시 수 = %d, want 1", got)
+	}
+	if want := (SendResult{Processed: 4, Skipped: 2}); result != want {
+		t.Errorf("다시 실행 result = %+v, want %+v", result, want)
+	}
+}
+
+func TestSendUnclassifiedLinks(t *testing.T) {
+	s := newMemoryStore()
+	useStore(t, s)
+
+	anyHour := 0
+	agrees := []setting.NotificationAgree{
+		{UserId: "user-1", IsClassifyAgree: true, NotifyHour: &anyHour},
+		// 기본 폴더가 없음
+		{UserId: "user-2", IsClassifyAgree: true, NotifyHour: &anyHour},
+	}
+	s.defaultLinkBookIds = map[string]string{"user-1": "LinkBook-1"}
+	s.linkBookCounts = map[string]int64{"LinkBook-1": 4}
+	s.devices = map[string][]setting.Device{
+		"user-1": {{UserId: "user-1", Token: "token-1", Platform: setting.PlatformAndroid}},
+	}
+
+	senders, sender := newTestSenders()
+
+	result, err := SendUnclassifiedLinks(context.Background(), "unclassified:2026-10-19", agrees, senders)
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+
+	if want := []string{"user-1"}; !equalStrings(s.savedNotifications(Unclassified), want) {
+		t.Errorf("저장한 알림 = %v, want %v", s.savedNotifications(Unclassified), want)
+	}
+	if want := []string{"token-1"}; !equalStrings(sentTokens(sender), want) {
+		t.Errorf("보낸 푸시 = %v, want %v", sentTokens(sender), want)
+	}
+	if want := (SendResult{Processed: 2, Sent: 1, Failed: 1}); result != want {
+		t.Errorf("result = %+v, want %+v", result, want)
+	}
+}
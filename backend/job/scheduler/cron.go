@@ -0,0 +1,171 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule 은 5 필드 cron 표현식(분 시 일 월 요일)입니다.
+// 각 필드는 *, 숫자, 범위(1-5), 간격(*/15, 0-30/10), 목록(1,15) 을 지원합니다. 요일은 0(일요일) ~ 7(일요일) 입니다.
+type Schedule struct {
+	expr   string
+	minute [60]bool
+	hour   [24]bool
+	dom    [32]bool
+	month  [13]bool
+	dow    [7]bool
+	// 일, 요일 필드가 * 인지. 둘 다 지정된 경우 cron 처럼 둘 중 하나만 맞아도 실행합니다.
+	domAny bool
+	dowAny bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseSchedule 은 "0 20 * * 3" 같은 cron 표현식을 읽습니다.
+func ParseSchedule(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	s := &Schedule{
+		expr:   strings.Join(fields, " "),
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+
+	for i, field := range fields {
+		values, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+		}
+
+		for _, v := range values {
+			switch i {
+			case 0:
+				s.minute[v] = true
+			case 1:
+				s.hour[v] = true
+			case 2:
+				s.dom[v] = true
+			case 3:
+				s.month[v] = true
+			case 4:
+				s.dow[v%7] = true
+			}
+		}
+	}
+
+	return s, nil
+}
+
+func parseCronField(field string, f cronField) ([]int, error) {
+	var values []int
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step %q in %s field", stepPart, f.name)
+			}
+			step = n
+		}
+
+		start, end := f.min, f.max
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+
+			n, err := strconv.Atoi(from)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q in %s field", rangePart, f.name)
+			}
+			start, end = n, n
+
+			if isRange {
+				n, err = strconv.Atoi(to)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value %q in %s field", rangePart, f.name)
+				}
+				end = n
+			} else if hasStep {
+				// 5/15 는 5 부터 끝까지 15 간격
+				end = f.max
+			}
+		}
+
+		if start < f.min || end > f.max || start > end {
+			return nil, fmt.Errorf("value %q out of range %d-%d in %s field", part, f.min, f.max, f.name)
+		}
+
+		for v := start; v <= end; v += step {
+			values = append(values, v)
+		}
+	}
+
+	return values, nil
+}
+
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next 는 t 이후(t 는 제외) 처음으로 실행할 시각을 반환합니다. t 의 시간대를 기준으로 계산합니다.
+// 5년 안에 실행할 시각이 없으면(2월 30일 등) zero time 을 반환합니다.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !s.month[t.Month()] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hour[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minute[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) matchDay(t time.Time) bool {
+	domMatch := s.dom[t.Day()]
+	dowMatch := s.dow[t.Weekday()]
+
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
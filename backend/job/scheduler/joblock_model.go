@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"joosum-backend/pkg/db"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type JobLockModel struct{}
+
+// JobLock 은 작업 이름별 임대(lease) 잠금입니다. (jobLocks 컬렉션)
+// 여러 스케줄러 인스턴스 중 잠금을 가진 하나만 작업을 실행합니다. 잠금을 가진 프로세스가 죽으면 expires_at 이 지난 뒤 다른 인스턴스가 가져갈 수 있습니다.
+type JobLock struct {
+	JobName    string    `bson:"_id"`
+	Owner      string    `bson:"owner"`
+	JobRunId   string    `bson:"job_run_id"`
+	AcquiredAt time.Time `bson:"acquired_at"`
+	ExpiresAt  time.Time `bson:"expires_at"`
+}
+
+// AcquireLock 은 잠금이 없거나 만료된 경우 jobRunId 실행의 잠금을 가져옵니다.
+// 유효한 잠금이 있으면 같은 owner(프로세스)의 다른 실행이 가진 잠금이어도 false 를 반환합니다.
+func (JobLockModel) AcquireLock(jobName, owner, jobRunId string, lease time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"_id":        jobName,
+		"expires_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{
+		"owner":       owner,
+		"job_run_id":  jobRunId,
+		"acquired_at": now,
+		"expires_at":  now.Add(lease),
+	}}
+
+	// 잠금 문서가 없으면 upsert 로 만들고, 유효한 잠금이 있으면 filter 가 맞지 않아 _id 중복 오류가 납니다.
+	_, err := db.JobLockCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RenewLock 은 jobRunId 실행이 가진 잠금의 만료 시각을 연장합니다. 잠금을 잃었으면 false 를 반환합니다.
+func (JobLockModel) RenewLock(jobName, owner, jobRunId string, lease time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": jobName, "owner": owner, "job_run_id": jobRunId}
+	update := bson.M{"$set": bson.M{"expires_at": time.Now().Add(lease)}}
+
+	result, err := db.JobLockCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// ReleaseLock 은 jobRunId 실행이 가진 잠금을 해제합니다. 그 사이 다른 실행이 가져간 잠금은 해제하지 않습니다.
+func (JobLockModel) ReleaseLock(jobName, owner, jobRunId string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.JobLockCollection.DeleteOne(ctx, bson.M{"_id": jobName, "owner": owner, "job_run_id": jobRunId})
+
+	return err
+}
+
+// GetLock 은 작업의 현재 잠금을 반환합니다. 잠금이 없으면 nil 입니다.
+func (JobLockModel) GetLock(jobName string) (*JobLock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var lock JobLock
+	err := db.JobLockCollection.FindOne(ctx, bson.M{"_id": jobName}).Decode(&lock)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &lock, nil
+}
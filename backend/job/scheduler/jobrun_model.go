@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"joosum-backend/pkg/db"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 실행 방식
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+	// 중단된 실행을 스케줄러 시작 시 이어서 실행
+	TriggerResume = "resume"
+)
+
+// 실행 결과
+const (
+	StatusRunning = "running"
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+	StatusPanic   = "panic"
+	// 종료 신호, 잠금 상실, 프로세스 종료 등으로 끝나지 못한 실행. 다음 실행이 같은 RunKey 로 이어서 처리합니다.
+	StatusInterrupted = "interrupted"
+)
+
+type JobRunModel struct{}
+
+// JobRun 은 작업 한 번의 실행 기록입니다. (jobRuns 컬렉션)
+type JobRun struct {
+	JobRunId string `bson:"_id"`
+	JobName  string `bson:"job_name"`
+	Trigger  string `bson:"trigger"`
+	// 멱등성 키. 작업은 사용자별로 "<RunKey>:<userId>" 같은 키를 남겨 같은 RunKey 로 다시 실행돼도 중복 처리하지 않습니다.
+	// 정기 실행은 "<작업>:<예정 시각>", 수동 실행은 "<작업>:<JobRunId>" 이며, 중단된 실행을 이어서 할 때는 이전 실행의 RunKey 를 씁니다.
+	RunKey string `bson:"run_key"`
+	// 이어서 실행한 경우 중단된 실행의 JobRunId
+	ResumedFrom string     `bson:"resumed_from,omitempty"`
+	Status      string     `bson:"status"`
+	StartedAt   time.Time  `bson:"started_at"`
+	EndedAt     *time.Time `bson:"ended_at,omitempty"`
+	// 처리 대상 수, 성공 수, 실패 수 (작업마다 의미가 다를 수 있습니다. 알림 작업은 알림 동의 사용자 수 / 발송 성공 / 실패)
+	Processed int `bson:"processed"`
+	Succeeded int `bson:"succeeded"`
+	Failed    int `bson:"failed"`
+	// 작업이 반환한 오류 또는 panic 메시지
+	Error string `bson:"error,omitempty"`
+	// 실행한 프로세스의 호스트명
+	Host string `bson:"host"`
+}
+
+func (JobRunModel) InsertJobRun(run JobRun) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.JobRunCollection.InsertOne(ctx, run)
+
+	return err
+}
+
+// FinishJobRun 은 실행이 끝난 기록의 상태, 종료 시각, 처리 수, 오류를 저장합니다.
+func (JobRunModel) FinishJobRun(run JobRun) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{
+		"status":    run.Status,
+		"ended_at":  run.EndedAt,
+		"processed": run.Processed,
+		"succeeded": run.Succeeded,
+		"failed":    run.Failed,
+		"error":     run.Error,
+	}}
+
+	_, err := db.JobRunCollection.UpdateByID(ctx, run.JobRunId, update)
+
+	return err
+}
+
+// GetLastJobRun 은 작업의 가장 최근 실행 기록을 반환합니다. 없으면 nil 입니다.
+func (JobRunModel) GetLastJobRun(jobName string) (*JobRun, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "started_at", Value: -1}})
+
+	var run JobRun
+	err := db.JobRunCollection.FindOne(ctx, bson.M{"job_name": jobName}, opts).Decode(&run)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+// MarkInterrupted 는 끝나지 못한(running) 실행 기록을 interrupted 로 바꿉니다.
+func (JobRunModel) MarkInterrupted(jobRunId string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": jobRunId, "status": StatusRunning}
+	update := bson.M{"$set": bson.M{"status": StatusInterrupted, "ended_at": time.Now()}}
+
+	_, err := db.JobRunCollection.UpdateOne(ctx, filter, update)
+
+	return err
+}
+
+// GetRecentJobRuns 는 작업의 최근 실행 기록을 최신순으로 반환합니다. jobName 이 비어 있으면 모든 작업을 조회합니다.
+func (JobRunModel) GetRecentJobRuns(jobName string, limit int64) ([]JobRun, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if jobName != "" {
+		filter["job_name"] = jobName
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "started_at", Value: -1}}).SetLimit(limit)
+
+	cur, err := db.JobRunCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := []JobRun{}
+	if err = cur.All(ctx, &runs); err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"context"
+
+	"joosum-backend/app/link"
+	"joosum-backend/job/notification"
+)
+
+// DefaultJobs 는 스케줄러에 등록하는 작업 목록입니다. 새 작업은 여기에 추가하고 scheduler-config.yml 에 실행 주기를 적습니다.
+func DefaultJobs() []Job {
+	return []Job{
+		{
+			Name:        notification.Unread,
+			Description: "읽지 않은 링크 알림",
+			Run: func(ctx context.Context, runKey string) (JobResult, error) {
+				return notificationJobResult(notification.SendUnreadLink(ctx, runKey))
+			},
+		},
+		{
+			Name:        notification.Unclassified,
+			Description: "분류되지 않은 링크 알림",
+			Run: func(ctx context.Context, runKey string) (JobResult, error) {
+				return notificationJobResult(notification.SendUnclassifiedLink(ctx, runKey))
+			},
+		},
+		{
+			Name:        notification.Reminder,
+			Description: "링크 리마인더 알림",
+			Run: func(ctx context.Context, runKey string) (JobResult, error) {
+				return notificationJobResult(notification.SendLinkReminders(ctx, runKey))
+			},
+		},
+		{
+			Name:        "embedding",
+			Description: "시맨틱 검색용 링크 임베딩",
+			Run: func(ctx context.Context, runKey string) (JobResult, error) {
+				result, err := link.LinkUsecase{}.SyncAllLinkEmbeddings(ctx)
+				return JobResult{
+					Processed: result.Users,
+					Succeeded: result.Users - result.Skipped - result.Failed,
+					Failed:    result.Failed,
+				}, err
+			},
+		},
+	}
+}
+
+func notificationJobResult(result notification.SendResult, err error) (JobResult, error) {
+	return JobResult{
+		Processed: result.Processed,
+		Succeeded: result.Sent,
+		Failed:    result.Failed,
+	}, err
+}
@@ -0,0 +1,348 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"joosum-backend/pkg/config"
+	"joosum-backend/pkg/util"
+)
+
+// JobResult 는 작업이 처리한 건수입니다. jobRuns 기록에 저장됩니다.
+type JobResult struct {
+	Processed int
+	Succeeded int
+	Failed    int
+}
+
+// Job 은 이름으로 등록하는 작업입니다.
+// 실행 주기는 scheduler-config.yml 의 jobs.<이름>.schedule 에서 읽으며, 비어 있으면 수동 실행(run)만 가능합니다.
+type Job struct {
+	Name        string
+	Description string
+	// runKey 는 실행의 멱등성 키입니다. 같은 runKey 로 다시 실행되면 이미 처리한 대상은 건너뛰어야 합니다.
+	Run func(ctx context.Context, runKey string) (JobResult, error)
+}
+
+var ErrJobLocked = errors.New("job is already running")
+
+const (
+	// 작업 하나의 최대 실행 시간
+	jobTimeout = 30 * time.Minute
+	// 잠금 유지 시간과 연장 주기. 프로세스가 죽으면 lockLease 뒤에 다른 인스턴스가 잠금을 가져갈 수 있습니다.
+	lockLease         = 2 * time.Minute
+	lockRenewInterval = 30 * time.Second
+	// 이 시간 안에 시작된 중단된 실행만 이어서 처리합니다. 더 오래된 실행은 중단으로만 기록합니다.
+	resumeWindow = 6 * time.Hour
+)
+
+type Scheduler struct {
+	jobs         []Job
+	location     *time.Location
+	jobRunModel  JobRunModel
+	jobLockModel JobLockModel
+	host         string
+	// 잠금 소유자. 같은 호스트의 여러 프로세스를 구분하기 위해 pid 와 임의 값을 붙입니다.
+	owner string
+	wg    sync.WaitGroup
+}
+
+// NewScheduler 는 작업 목록으로 스케줄러를 만듭니다. cron 표현식은 schedulerTimezone (기본 Asia/Seoul) 기준입니다.
+func NewScheduler(jobs []Job) (*Scheduler, error) {
+	timezone := config.GetEnvConfig("schedulerTimezone")
+	if timezone == "" {
+		timezone = "Asia/Seoul"
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedulerTimezone %q: %v", timezone, err)
+	}
+
+	names := map[string]bool{}
+	for _, job := range jobs {
+		if names[job.Name] {
+			return nil, fmt.Errorf("duplicate job name %q", job.Name)
+		}
+		names[job.Name] = true
+	}
+
+	host, _ := os.Hostname()
+	owner := fmt.Sprintf("%s:%d:%s", host, os.Getpid(), util.CreateId("Scheduler"))
+
+	return &Scheduler{jobs: jobs, location: location, host: host, owner: owner}, nil
+}
+
+func (s *Scheduler) Jobs() []Job {
+	return s.jobs
+}
+
+func (s *Scheduler) Location() *time.Location {
+	return s.location
+}
+
+// JobSchedule 은 설정에 적힌 작업의 실행 주기를 반환합니다. 설정이 없으면 nil 입니다.
+func (s *Scheduler) JobSchedule(name string) (*Schedule, error) {
+	expr := config.GetEnvConfig("jobs." + name + ".schedule")
+	if expr == "" {
+		return nil, nil
+	}
+
+	return ParseSchedule(expr)
+}
+
+// Start 는 실행 주기가 있는 작업마다 고루틴을 띄워 주기적으로 실행합니다.
+// 같은 작업은 이전 실행이 끝난 뒤에 다음 시각을 계산하고, 중단된 실행을 이어서 처리하는 중이거나
+// 다른 인스턴스가 실행 중이면 잠금을 얻지 못해 건너뛰므로 겹쳐서 실행되지 않습니다.
+// ctx 가 취소되면 새 실행을 시작하지 않으며, Wait 로 실행 중인 작업이 끝날 때까지 기다릴 수 있습니다.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.resumeInterrupted(ctx)
+	}()
+
+	scheduled := 0
+
+	for _, job := range s.jobs {
+		schedule, err := s.JobSchedule(job.Name)
+		if err != nil {
+			return fmt.Errorf("job %s: %v", job.Name, err)
+		}
+		if schedule == nil {
+			log.Printf("[스케줄러] %s: 실행 주기가 없어 수동 실행만 가능합니다.", job.Name)
+			continue
+		}
+
+		scheduled++
+		s.wg.Add(1)
+		go s.loop(ctx, job, schedule)
+	}
+
+	log.Printf("[스케줄러] %d 개의 작업을 등록했습니다. (timezone=%s)", scheduled, s.location)
+
+	return nil
+}
+
+// Wait 는 Start 로 띄운 작업 고루틴이 모두 끝날 때까지 기다립니다.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job, schedule *Schedule) {
+	defer s.wg.Done()
+
+	for {
+		next := schedule.Next(time.Now().In(s.location))
+		if next.IsZero() {
+			log.Printf("[스케줄러] %s: 다음 실행 시각이 없어 중단합니다. (schedule=%s)", job.Name, schedule)
+			return
+		}
+		log.Printf("[스케줄러] %s: 다음 실행 %s", job.Name, next.Format(time.RFC3339))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// 여러 인스턴스가 같은 시각에 실행해도 RunKey 가 같으므로 사용자별로 한 번만 처리됩니다.
+		// 오류는 jobRuns 에 기록되므로 여기서는 다음 실행을 계속합니다.
+		s.runJob(ctx, job, TriggerSchedule, job.Name+":"+next.Format(time.RFC3339))
+	}
+}
+
+// RunJob 은 작업을 지금 한 번 실행하고 jobRuns 에 기록을 남깁니다.
+// 이 프로세스나 다른 인스턴스에서 실행 중이면 ErrJobLocked 를 반환합니다.
+func (s *Scheduler) RunJob(ctx context.Context, name string, trigger string) (*JobRun, error) {
+	job, ok := s.findJob(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown job %q", name)
+	}
+
+	return s.runJob(ctx, job, trigger, "")
+}
+
+// runJob 은 잠금을 가져와 작업을 실행합니다.
+// 마지막 실행이 중단됐고 resumeWindow 안에 시작됐으면 그 RunKey 를 이어받아, 이미 처리한 사용자는 건너뛰고 나머지만 처리합니다.
+// 작업에서 panic 이 나도 스케줄러는 멈추지 않고, 실행 기록의 상태를 panic 으로 남깁니다.
+func (s *Scheduler) runJob(ctx context.Context, job Job, trigger string, runKey string) (*JobRun, error) {
+	run := JobRun{
+		JobRunId:  util.CreateId("JobRun"),
+		JobName:   job.Name,
+		Trigger:   trigger,
+		RunKey:    runKey,
+		Status:    StatusRunning,
+		StartedAt: time.Now(),
+		Host:      s.host,
+	}
+	if run.RunKey == "" {
+		run.RunKey = job.Name + ":" + run.JobRunId
+	}
+
+	acquired, err := s.jobLockModel.AcquireLock(job.Name, s.owner, run.JobRunId, lockLease)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock for job %s: %v", job.Name, err)
+	}
+	if !acquired {
+		log.Printf("[스케줄러] %s: 이미 실행 중이라 건너뜁니다. (trigger=%s)", job.Name, trigger)
+		return nil, ErrJobLocked
+	}
+	defer func() {
+		if err := s.jobLockModel.ReleaseLock(job.Name, s.owner, run.JobRunId); err != nil {
+			log.Printf("[스케줄러] %s: 잠금 해제 실패: %v", job.Name, err)
+		}
+	}()
+
+	// 잠금을 잃으면(만료 후 다른 인스턴스가 가져감) 작업을 중단합니다.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go s.keepLock(ctx, cancel, job.Name, run.JobRunId)
+
+	// 마지막 실행이 끝나지 못했으면 이어서 처리합니다.
+	// 잠금을 가진 상태이므로 running 으로 남은 기록은 실행 중이 아니라 프로세스가 죽어 중단된 실행입니다.
+	interrupted, err := s.interruptedRun(job.Name)
+	if err != nil {
+		return nil, err
+	}
+	if interrupted != nil {
+		if err := s.jobRunModel.MarkInterrupted(interrupted.JobRunId); err != nil {
+			return nil, err
+		}
+		run.RunKey = interrupted.RunKey
+		run.ResumedFrom = interrupted.JobRunId
+		log.Printf("[스케줄러] %s: 중단된 실행(%s)을 이어서 처리합니다. (runKey=%s)", job.Name, interrupted.JobRunId, run.RunKey)
+	}
+
+	if err := s.jobRunModel.InsertJobRun(run); err != nil {
+		// 기록 저장에 실패해도 작업은 실행합니다.
+		log.Printf("[스케줄러] %s: 실행 기록 저장 실패: %v", job.Name, err)
+	}
+
+	log.Printf("[스케줄러] %s: 실행 시작 (trigger=%s, id=%s, runKey=%s)", job.Name, trigger, run.JobRunId, run.RunKey)
+
+	result, err := s.runSafely(ctx, job, &run)
+
+	endedAt := time.Now()
+	run.EndedAt = &endedAt
+	run.Processed = result.Processed
+	run.Succeeded = result.Succeeded
+	run.Failed = result.Failed
+	if err != nil {
+		run.Error = err.Error()
+		if run.Status == StatusRunning {
+			run.Status = StatusFailed
+			// 종료 신호나 잠금 상실로 중단된 경우 다음 실행이 이어서 처리합니다.
+			if ctx.Err() != nil {
+				run.Status = StatusInterrupted
+			}
+		}
+	} else {
+		run.Status = StatusSuccess
+	}
+
+	if saveErr := s.jobRunModel.FinishJobRun(run); saveErr != nil {
+		log.Printf("[스케줄러] %s: 실행 기록 저장 실패: %v", job.Name, saveErr)
+	}
+
+	log.Printf("[스케줄러] %s: 실행 종료 (status=%s, processed=%d, succeeded=%d, failed=%d, duration=%s)",
+		job.Name, run.Status, run.Processed, run.Succeeded, run.Failed, endedAt.Sub(run.StartedAt).Round(time.Millisecond))
+	if err != nil {
+		log.Printf("[스케줄러] %s: %v", job.Name, err)
+	}
+
+	return &run, err
+}
+
+// keepLock 은 작업이 끝날 때까지 잠금을 연장합니다. 잠금을 잃으면 cancel 로 작업을 중단시킵니다.
+func (s *Scheduler) keepLock(ctx context.Context, cancel context.CancelFunc, jobName, jobRunId string) {
+	ticker := time.NewTicker(lockRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		ok, err := s.jobLockModel.RenewLock(jobName, s.owner, jobRunId, lockLease)
+		if err != nil {
+			// 일시적인 DB 오류는 다음 연장에서 다시 시도합니다. 만료 전까지는 잠금이 유지됩니다.
+			log.Printf("[스케줄러] %s: 잠금 연장 실패: %v", jobName, err)
+			continue
+		}
+		if !ok {
+			log.Printf("[스케줄러] %s: 잠금을 잃어 작업을 중단합니다.", jobName)
+			cancel()
+			return
+		}
+	}
+}
+
+// interruptedRun 은 작업의 마지막 실행이 중단됐고 resumeWindow 안에 시작됐으면 그 기록을 반환합니다.
+func (s *Scheduler) interruptedRun(jobName string) (*JobRun, error) {
+	last, err := s.jobRunModel.GetLastJobRun(jobName)
+	if err != nil || last == nil {
+		return nil, err
+	}
+
+	if last.Status != StatusRunning && last.Status != StatusInterrupted {
+		return nil, nil
+	}
+	if time.Since(last.StartedAt) >= resumeWindow {
+		return nil, nil
+	}
+
+	return last, nil
+}
+
+// resumeInterrupted 는 마지막 실행이 중단된 작업을 이어서 실행합니다.
+func (s *Scheduler) resumeInterrupted(ctx context.Context) {
+	for _, job := range s.jobs {
+		interrupted, err := s.interruptedRun(job.Name)
+		if err != nil {
+			log.Printf("[스케줄러] %s: 중단된 실행 조회 실패: %v", job.Name, err)
+			continue
+		}
+		if interrupted == nil {
+			continue
+		}
+
+		// 실행 중인 다른 인스턴스가 있으면 runJob 이 잠금을 얻지 못해 건너뜁니다.
+		// 이어서 처리하는 동안 돌아온 정기 실행도 잠금을 얻지 못해 건너뜁니다.
+		s.runJob(ctx, job, TriggerResume, "")
+	}
+}
+
+// runSafely 는 작업의 panic 을 오류로 바꿉니다.
+func (s *Scheduler) runSafely(ctx context.Context, job Job, run *JobRun) (result JobResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			run.Status = StatusPanic
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+
+	return job.Run(ctx, run.RunKey)
+}
+
+func (s *Scheduler) findJob(name string) (Job, bool) {
+	for _, job := range s.jobs {
+		if job.Name == name {
+			return job, true
+		}
+	}
+	return Job{}, false
+}
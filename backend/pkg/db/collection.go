@@ -47,6 +47,15 @@ func LinkEnsureIndexes(collection *mongo.Collection) error {
 		Options: options.Index().SetUnique(true),
 	}
 
+	// 링크북별 링크 집계($lookup)용 인덱스
+	linkBookIdIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "link_book_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+		Options: options.Index().SetUnique(false),
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -58,6 +67,12 @@ func LinkEnsureIndexes(collection *mongo.Collection) error {
 
 	_, err = collection.Indexes().CreateOne(ctx, linkIdIndexModel)
 
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.Indexes().CreateOne(ctx, linkBookIdIndexModel)
+
 	return err
 }
 
@@ -66,6 +81,173 @@ func InitLinkCollection(client *mongo.Client, dbName string) {
 	LinkEnsureIndexes(LinkCollection)
 }
 
+var LinkContentCollection *mongo.Collection
+
+// 링크 본문(AI 요약, 검색 등에 사용)은 목록 조회 시 함께 읽히지 않도록 별도 컬렉션에 저장
+func LinkContentEnsureIndexes(collection *mongo.Collection) error {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+		},
+		Options: options.Index().SetUnique(false),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateOne(ctx, indexModel)
+	return err
+}
+
+func InitLinkContentCollection(client *mongo.Client, dbName string) {
+	LinkContentCollection = client.Database(dbName).Collection("linkContents")
+	LinkContentEnsureIndexes(LinkContentCollection)
+}
+
+var LinkEmbeddingCollection *mongo.Collection
+
+// 벡터 검색은 사용자 + 임베딩 모델 단위로 이루어짐
+// Atlas Vector Search 를 쓰는 경우 vector 필드의 검색 인덱스는 Atlas 에서 따로 생성 (vectorSearchIndex 설정)
+func LinkEmbeddingEnsureIndexes(collection *mongo.Collection) error {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "model", Value: 1},
+		},
+		Options: options.Index().SetUnique(false),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateOne(ctx, indexModel)
+	return err
+}
+
+func InitLinkEmbeddingCollection(client *mongo.Client, dbName string) {
+	LinkEmbeddingCollection = client.Database(dbName).Collection("linkEmbeddings")
+	LinkEmbeddingEnsureIndexes(LinkEmbeddingCollection)
+}
+
+var LinkAutoFilingCollection *mongo.Collection
+
+func LinkAutoFilingEnsureIndexes(collection *mongo.Collection) error {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+		Options: options.Index().SetUnique(false),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateOne(ctx, indexModel)
+	return err
+}
+
+func InitLinkAutoFilingCollection(client *mongo.Client, dbName string) {
+	LinkAutoFilingCollection = client.Database(dbName).Collection("linkAutoFilings")
+	LinkAutoFilingEnsureIndexes(LinkAutoFilingCollection)
+}
+
+var LinkReminderCollection *mongo.Collection
+
+// 리마인더 작업이 알림 시각이 지난 예약을 찾는 인덱스, 링크/사용자별 예약을 찾는 인덱스 생성
+func LinkReminderEnsureIndexes(collection *mongo.Collection) error {
+	dueIndexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "remind_at", Value: 1}},
+	}
+	linkIndexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "link_id", Value: 1}, {Key: "status", Value: 1}},
+	}
+	userIdIndexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "status", Value: 1}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{dueIndexModel, linkIndexModel, userIdIndexModel})
+	return err
+}
+
+func InitLinkReminderCollection(client *mongo.Client, dbName string) {
+	LinkReminderCollection = client.Database(dbName).Collection("linkReminders")
+	LinkReminderEnsureIndexes(LinkReminderCollection)
+}
+
+var AIUsageCollection *mongo.Collection
+
+func AIUsageEnsureIndexes(collection *mongo.Collection) error {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+		Options: options.Index().SetUnique(false),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateOne(ctx, indexModel)
+	return err
+}
+
+func InitAIUsageCollection(client *mongo.Client, dbName string) {
+	AIUsageCollection = client.Database(dbName).Collection("aiUsages")
+	AIUsageEnsureIndexes(AIUsageCollection)
+}
+
+var AITagCacheCollection *mongo.Collection
+
+// AI 태그 추천 캐시는 7일이 지나면 자동 삭제 (페이지 내용이 바뀔 수 있으므로)
+func AITagCacheEnsureIndexes(collection *mongo.Collection) error {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "created_at", Value: 1},
+		},
+		Options: options.Index().SetExpireAfterSeconds(7 * 24 * 60 * 60),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateOne(ctx, indexModel)
+	return err
+}
+
+func InitAITagCacheCollection(client *mongo.Client, dbName string) {
+	AITagCacheCollection = client.Database(dbName).Collection("aiTagCache")
+	AITagCacheEnsureIndexes(AITagCacheCollection)
+}
+
+var AIPromptCollection *mongo.Collection
+
+// 프롬프트 이름과 버전으로 하나의 템플릿만 저장
+func AIPromptEnsureIndexes(collection *mongo.Collection) error {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "name", Value: 1},
+			{Key: "version", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateOne(ctx, indexModel)
+	return err
+}
+
+func InitAIPromptCollection(client *mongo.Client, dbName string) {
+	AIPromptCollection = client.Database(dbName).Collection("aiPrompts")
+	AIPromptEnsureIndexes(AIPromptCollection)
+}
+
 var LinkBookCollection *mongo.Collection
 
 func InitLinkBookCollection(client *mongo.Client, dbName string) {
@@ -123,8 +305,66 @@ func EnsureIndexes(collection *mongo.Collection) error {
 
 var NotificationCollection *mongo.Collection
 
+// 알림은 만든 지 이 기간이 지나면 TTL 인덱스로 지워집니다. (알림함에서 보이는 기간)
+const NotificationRetentionDays = 30
+
+// 알림 작업이 같은 실행에서 사용자에게 알림을 두 번 저장하지 않도록 멱등성 키에 unique 인덱스 생성 (키가 있는 문서만)
+// 알림 빈도를 확인할 때 사용자의 종류별 마지막 알림을 찾는 인덱스 생성
+// NotificationRetentionDays 가 지난 알림을 지우는 TTL 인덱스 생성
+func NotificationEnsureIndexes(collection *mongo.Collection) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "idempotency_key", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"idempotency_key": bson.M{"$exists": true}}),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "type", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys:    bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(NotificationRetentionDays * 24 * 60 * 60),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, indexModels)
+	return err
+}
+
 func InitNotificationCollection(client *mongo.Client, dbName string) {
 	NotificationCollection = client.Database(dbName).Collection("notifications")
+	NotificationEnsureIndexes(NotificationCollection)
+}
+
+var DeviceCollection *mongo.Collection
+
+// 토큰은 한 사용자에게만 속하고, 사용자별 디바이스 조회용 인덱스
+func DeviceEnsureIndexes(collection *mongo.Collection) error {
+	tokenIndexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "token", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+
+	userIdIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "last_seen_at", Value: -1},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{tokenIndexModel, userIdIndexModel})
+	return err
+}
+
+func InitDeviceCollection(client *mongo.Client, dbName string) {
+	DeviceCollection = client.Database(dbName).Collection("devices")
+	DeviceEnsureIndexes(DeviceCollection)
 }
 
 var NotificationAgreeCollection *mongo.Collection
@@ -138,3 +378,79 @@ var BannerCollection *mongo.Collection
 func InitBannerCollection(client *mongo.Client, dbName string) {
 	BannerCollection = client.Database(dbName).Collection("banners")
 }
+
+var JobRunCollection *mongo.Collection
+
+// 작업별 최근 실행 기록 조회용 인덱스
+func JobRunEnsureIndexes(collection *mongo.Collection) error {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "job_name", Value: 1},
+			{Key: "started_at", Value: -1},
+		},
+		Options: options.Index().SetUnique(false),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateOne(ctx, indexModel)
+	return err
+}
+
+func InitJobRunCollection(client *mongo.Client, dbName string) {
+	JobRunCollection = client.Database(dbName).Collection("jobRuns")
+	JobRunEnsureIndexes(JobRunCollection)
+}
+
+var JobLockCollection *mongo.Collection
+
+func InitJobLockCollection(client *mongo.Client, dbName string) {
+	JobLockCollection = client.Database(dbName).Collection("jobLocks")
+}
+
+var NotificationDeliveryCollection *mongo.Collection
+
+// 발송 결과는 30일 뒤 자동 삭제
+func NotificationDeliveryEnsureIndexes(collection *mongo.Collection) error {
+	ttlIndexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "created_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(30 * 24 * 60 * 60),
+	}
+
+	runKeyIndexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "run_key", Value: 1}, {Key: "status", Value: 1}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{ttlIndexModel, runKeyIndexModel})
+	return err
+}
+
+func InitNotificationDeliveryCollection(client *mongo.Client, dbName string) {
+	NotificationDeliveryCollection = client.Database(dbName).Collection("notificationDeliveries")
+	NotificationDeliveryEnsureIndexes(NotificationDeliveryCollection)
+}
+
+var RateLimitCollection *mongo.Collection
+
+// 요청 수 제한 기록은 expires_at 이 지나면 자동 삭제
+func RateLimitEnsureIndexes(collection *mongo.Collection) error {
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateOne(ctx, indexModel)
+	return err
+}
+
+func InitRateLimitCollection(client *mongo.Client, dbName string) {
+	RateLimitCollection = client.Database(dbName).Collection("rateLimits")
+	RateLimitEnsureIndexes(RateLimitCollection)
+}
@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"fmt"
+)
+
+// 언어별 복수형 규칙. forms 에서 몇 번째 형태를 쓸지 반환합니다.
+// 새 언어를 추가하면 여기에 규칙을 적습니다. 없는 언어는 영어와 같이 1 이면 단수, 나머지는 복수입니다.
+var pluralRules = map[string]func(n int64) int{
+	// 한국어, 일본어, 중국어는 복수형이 없음
+	"ko": func(n int64) int { return 0 },
+	"ja": func(n int64) int { return 0 },
+	"zh": func(n int64) int { return 0 },
+	"en": oneOther,
+}
+
+func oneOther(n int64) int {
+	if n == 1 || n == -1 {
+		return 0
+	}
+	return 1
+}
+
+// plural 은 locale 규칙으로 n 에 맞는 형태를 고릅니다. ({{plural .Count "link" "links"}})
+// 규칙이 고른 형태가 없으면 마지막 형태를 씁니다.
+func plural(locale string, n interface{}, forms ...string) (string, error) {
+	if len(forms) == 0 {
+		return "", fmt.Errorf("plural: no forms")
+	}
+
+	var count int64
+	switch v := n.(type) {
+	case int:
+		count = int64(v)
+	case int32:
+		count = int64(v)
+	case int64:
+		count = v
+	default:
+		return "", fmt.Errorf("plural: not an integer: %v", n)
+	}
+
+	rule, ok := pluralRules[locale]
+	if !ok {
+		rule = oneOther
+	}
+
+	index := rule(count)
+	if index >= len(forms) {
+		index = len(forms) - 1
+	}
+	return forms[index], nil
+}
@@ -0,0 +1,193 @@
+package i18n
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// 언어별 문구. locales/<언어>.json 에 템플릿 이름별 title, body 를 적습니다.
+//
+//	{"notification.unread": {"title": "읽지 않은 링크가 {{.Count}}건 있어요.", "body": "..."}}
+//
+// title, body 는 text/template 형식이며 이름 있는 값({{.Count}})과 복수형({{plural .Count "link" "links"}})을 쓸 수 있습니다.
+//
+//go:embed locales
+var builtinLocales embed.FS
+
+// 사용자 언어가 없거나 지원하지 않는 언어일 때 사용하는 언어
+const DefaultLocale = "ko"
+
+// Message 는 템플릿으로 만든 제목과 본문입니다.
+type Message struct {
+	Title string
+	Body  string
+}
+
+type messageTemplate struct {
+	title *template.Template
+	body  *template.Template
+}
+
+var catalog struct {
+	once sync.Once
+	// 언어 -> 템플릿 이름 -> 템플릿
+	templates map[string]map[string]messageTemplate
+	err       error
+}
+
+func load() (map[string]map[string]messageTemplate, error) {
+	catalog.once.Do(func() {
+		catalog.templates, catalog.err = loadLocales(builtinLocales, "locales")
+	})
+	return catalog.templates, catalog.err
+}
+
+func loadLocales(fsys fs.FS, dir string) (map[string]map[string]messageTemplate, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := map[string]map[string]messageTemplate{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		locale := strings.ToLower(strings.TrimSuffix(entry.Name(), ".json"))
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var texts map[string]struct {
+			Title string `json:"title"`
+			Body  string `json:"body"`
+		}
+		if err := json.Unmarshal(data, &texts); err != nil {
+			return nil, fmt.Errorf("i18n: %s: %v", entry.Name(), err)
+		}
+
+		templates[locale] = map[string]messageTemplate{}
+		for name, text := range texts {
+			title, err := parseTemplate(locale, name+".title", text.Title)
+			if err != nil {
+				return nil, err
+			}
+			body, err := parseTemplate(locale, name+".body", text.Body)
+			if err != nil {
+				return nil, err
+			}
+			templates[locale][name] = messageTemplate{title: title, body: body}
+		}
+	}
+
+	if _, ok := templates[DefaultLocale]; !ok {
+		return nil, fmt.Errorf("i18n: default locale %s not found", DefaultLocale)
+	}
+
+	return templates, nil
+}
+
+func parseTemplate(locale, name, text string) (*template.Template, error) {
+	funcs := template.FuncMap{
+		"plural": func(n interface{}, forms ...string) (string, error) {
+			return plural(locale, n, forms...)
+		},
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("i18n: %s/%s: %v", locale, name, err)
+	}
+	return tmpl, nil
+}
+
+// Locales 는 지원하는 언어 목록입니다.
+func Locales() []string {
+	templates, err := load()
+	if err != nil {
+		return []string{DefaultLocale}
+	}
+
+	locales := make([]string, 0, len(templates))
+	for locale := range templates {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// ResolveLocale 은 사용자 언어(ko, en, en-US, en_US 등)에 맞는 지원 언어를 고릅니다.
+// 같은 언어가 없으면 언어 부분(en-US -> en)으로 찾고, 그래도 없으면 기본 언어(ko)입니다.
+func ResolveLocale(locale string) string {
+	templates, err := load()
+	if err != nil {
+		return DefaultLocale
+	}
+
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if _, ok := templates[locale]; ok {
+		return locale
+	}
+	if language, _, ok := strings.Cut(locale, "-"); ok {
+		if _, ok := templates[language]; ok {
+			return language
+		}
+	}
+	return DefaultLocale
+}
+
+// IsSupported 는 locale 에 맞는 언어가 있는지 확인합니다. (기본 언어로 대신하지 않고)
+func IsSupported(locale string) bool {
+	resolved := ResolveLocale(locale)
+	if resolved != DefaultLocale {
+		return true
+	}
+	language, _, _ := strings.Cut(strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")), "-")
+	return language == DefaultLocale
+}
+
+// Render 는 name 템플릿의 locale 문구에 data 를 넣어 제목과 본문을 만듭니다.
+// 해당 언어에 템플릿이 없으면 기본 언어(ko) 문구를 사용합니다.
+func Render(name, locale string, data interface{}) (Message, error) {
+	templates, err := load()
+	if err != nil {
+		return Message{}, err
+	}
+
+	tmpl, ok := templates[ResolveLocale(locale)][name]
+	if !ok {
+		tmpl, ok = templates[DefaultLocale][name]
+		if !ok {
+			return Message{}, fmt.Errorf("i18n: template not found: %s", name)
+		}
+	}
+
+	title, err := execute(tmpl.title, data)
+	if err != nil {
+		return Message{}, err
+	}
+	body, err := execute(tmpl.body, data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{Title: title, Body: body}, nil
+}
+
+func execute(tmpl *template.Template, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("i18n: %v", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,62 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"joosum-backend/pkg/config"
+)
+
+const DefaultEmbeddingModel = "text-embedding-3-small"
+
+type EmbeddingResponse struct {
+	// 입력 순서와 같은 순서의 벡터
+	Vectors      [][]float32
+	Model        string
+	PromptTokens int
+}
+
+// EmbeddingProvider 는 텍스트를 벡터로 바꾸는 임베딩 호출을 추상화합니다.
+type EmbeddingProvider interface {
+	// Name 은 제공자 이름을 반환합니다. (openai, local, fake)
+	Name() string
+	// Model 은 임베딩 모델명을 반환합니다. 모델이 다르면 벡터를 서로 비교할 수 없습니다.
+	Model() string
+	Embed(ctx context.Context, texts []string) (*EmbeddingResponse, error)
+}
+
+// NewEmbeddingProvider 는 설정값(embeddingProvider, embeddingModel)에 따라 EmbeddingProvider 를 생성합니다.
+// embeddingProvider 가 비어있으면 llmProvider 와 같은 제공자를 사용합니다.
+func NewEmbeddingProvider() (EmbeddingProvider, error) {
+	name := config.GetEnvConfig("embeddingProvider")
+	if name == "" {
+		name = config.GetEnvConfig("llmProvider")
+	}
+
+	model := config.GetEnvConfig("embeddingModel")
+	if model == "" {
+		model = DefaultEmbeddingModel
+	}
+
+	switch name {
+	case "", ProviderOpenAI:
+		apiKey := config.GetEnvConfig("openaiApiKey")
+		if apiKey == "" {
+			return nil, fmt.Errorf("OpenAI API key not configured")
+		}
+		return NewOpenAIEmbeddingProvider(apiKey, model), nil
+
+	case ProviderLocal:
+		baseURL := config.GetEnvConfig("llmBaseURL")
+		if baseURL == "" {
+			return nil, fmt.Errorf("llmBaseURL not configured for local embedding provider")
+		}
+		return NewLocalEmbeddingProvider(baseURL, config.GetEnvConfig("llmApiKey"), model), nil
+
+	case ProviderFake:
+		return NewFakeEmbeddingProvider(0), nil
+
+	default:
+		return nil, fmt.Errorf("unknown embeddingProvider: %s", name)
+	}
+}
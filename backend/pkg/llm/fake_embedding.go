@@ -0,0 +1,92 @@
+package llm
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const defaultFakeEmbeddingDimensions = 256
+
+// FakeEmbeddingProvider 는 네트워크 호출 없이 단어 해싱으로 벡터를 만드는 제공자입니다.
+// 같은 단어(한글은 두 글자 단위)를 많이 공유하는 텍스트일수록 유사도가 높아 로컬 개발과 테스트에 사용할 수 있습니다.
+type FakeEmbeddingProvider struct {
+	Dimensions int
+}
+
+func NewFakeEmbeddingProvider(dimensions int) *FakeEmbeddingProvider {
+	if dimensions <= 0 {
+		dimensions = defaultFakeEmbeddingDimensions
+	}
+	return &FakeEmbeddingProvider{Dimensions: dimensions}
+}
+
+func (p *FakeEmbeddingProvider) Name() string {
+	return ProviderFake
+}
+
+func (p *FakeEmbeddingProvider) Model() string {
+	return ProviderFake
+}
+
+func (p *FakeEmbeddingProvider) Embed(ctx context.Context, texts []string) (*EmbeddingResponse, error) {
+	vectors := make([][]float32, 0, len(texts))
+	tokens := 0
+	for _, text := range texts {
+		vectors = append(vectors, p.embed(text))
+		tokens += len([]rune(text)) / 4
+	}
+
+	return &EmbeddingResponse{
+		Vectors:      vectors,
+		Model:        ProviderFake,
+		PromptTokens: tokens,
+	}, nil
+}
+
+func (p *FakeEmbeddingProvider) embed(text string) []float32 {
+	vector := make([]float32, p.Dimensions)
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, word := range words {
+		p.add(vector, word)
+
+		// 조사가 붙은 한글 단어도 비슷하게 보이도록 두 글자 단위로도 더함
+		runes := []rune(word)
+		for i := 0; i+1 < len(runes); i++ {
+			p.add(vector, string(runes[i:i+2]))
+		}
+	}
+
+	var norm float64
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return vector
+	}
+
+	scale := float32(1 / math.Sqrt(norm))
+	for i := range vector {
+		vector[i] *= scale
+	}
+
+	return vector
+}
+
+func (p *FakeEmbeddingProvider) add(vector []float32, token string) {
+	h := fnv.New64a()
+	h.Write([]byte(token))
+	sum := h.Sum64()
+
+	// 해시 충돌이 한쪽으로 쌓이지 않도록 최상위 비트로 부호를 정함
+	value := float32(1)
+	if sum>>63 == 1 {
+		value = -1
+	}
+	vector[sum%uint64(len(vector))] += value
+}
@@ -0,0 +1,72 @@
+package llm
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeProvider 는 네트워크 호출 없이 정해진 응답을 돌려주는 제공자입니다.
+// 테스트와 로컬 개발에서 사용하며, 받은 요청을 기록합니다.
+type FakeProvider struct {
+	// Respond 가 설정되어 있으면 요청마다 호출해 응답을 만듭니다.
+	Respond func(req ChatRequest) (string, error)
+	// Respond 가 없을 때 항상 반환하는 응답
+	Response string
+
+	mu       sync.Mutex
+	requests []ChatRequest
+}
+
+func NewFakeProvider(response string) *FakeProvider {
+	if response == "" {
+		response = "[]"
+	}
+	return &FakeProvider{Response: response}
+}
+
+func (p *FakeProvider) Name() string {
+	return ProviderFake
+}
+
+func (p *FakeProvider) Model() string {
+	return ProviderFake
+}
+
+func (p *FakeProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	p.mu.Lock()
+	p.requests = append(p.requests, req)
+	p.mu.Unlock()
+
+	content := p.Response
+	if p.Respond != nil {
+		var err error
+		content, err = p.Respond(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ChatResponse{
+		Content:          content,
+		Model:            ProviderFake,
+		PromptTokens:     countTokens(req.Messages),
+		CompletionTokens: len([]rune(content)) / 4,
+	}, nil
+}
+
+// Requests 는 지금까지 받은 요청 목록을 반환합니다.
+func (p *FakeProvider) Requests() []ChatRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]ChatRequest(nil), p.requests...)
+}
+
+// countTokens 는 대략적인 토큰 수(4글자당 1토큰)를 계산합니다.
+func countTokens(messages []Message) int {
+	count := 0
+	for _, m := range messages {
+		count += len([]rune(m.Content)) / 4
+	}
+	return count
+}
@@ -0,0 +1,256 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// ResponseFormat 은 응답 JSON 의 스키마입니다.
+// structured output 을 지원하는 제공자는 이 스키마로 응답을 강제하며, 나머지는 프롬프트의 형식 안내에 의존합니다.
+// OpenAI 는 최상위가 객체인 스키마만 받으므로 배열은 {"tags": [...]} 처럼 객체로 감쌉니다.
+type ResponseFormat struct {
+	Name   string
+	Schema json.RawMessage
+}
+
+// 파싱에 실패한 응답을 고쳐 달라고 다시 요청할 때의 메시지
+const repairPrompt = "이전 응답을 JSON 으로 파싱하지 못했습니다 (%v). 설명이나 코드 블록 없이 요청한 형식의 JSON 만 다시 응답하세요."
+
+// ChatJSON 은 JSON 응답을 요청해 v 로 디코딩합니다.
+// 응답에서 JSON 을 관대하게 추출(ExtractJSON)하고, 그래도 디코딩하지 못하면 오류를 알려주고 한 번 더 요청합니다.
+// v 가 배열인데 응답이 {"tags": [...]} 처럼 배열 필드 하나뿐인 객체면 그 배열을 디코딩합니다.
+func ChatJSON(ctx context.Context, provider LLMProvider, req ChatRequest, v interface{}) (*ChatResponse, error) {
+	resp, err := provider.Chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	parseErr := decodeJSONResponse(resp.Content, v)
+	if parseErr == nil {
+		return resp, nil
+	}
+
+	log.Printf("[LLM] JSON 응답 파싱 실패, 다시 요청 (provider=%s, response=%s, err=%v)", provider.Name(), resp.Content, parseErr)
+
+	repairReq := req
+	repairReq.Messages = append(append([]Message(nil), req.Messages...),
+		Message{Role: RoleAssistant, Content: resp.Content},
+		Message{Role: RoleUser, Content: fmt.Sprintf(repairPrompt, parseErr)},
+	)
+
+	resp, err = provider.Chat(ctx, repairReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := decodeJSONResponse(resp.Content, v); err != nil {
+		return nil, fmt.Errorf("failed to parse AI response as JSON: %v, response: %s", err, resp.Content)
+	}
+
+	return resp, nil
+}
+
+func decodeJSONResponse(content string, v interface{}) error {
+	text, err := ExtractJSON(content)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal([]byte(text), v)
+	if err == nil {
+		return nil
+	}
+
+	// structured output 으로 배열을 객체로 감싸 받은 경우
+	if inner, ok := singleArrayField([]byte(text)); ok {
+		if innerErr := json.Unmarshal(inner, v); innerErr == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// singleArrayField 는 {"key": [...]} 처럼 배열 필드 하나만 있는 객체에서 그 배열을 꺼냅니다.
+func singleArrayField(data []byte) (json.RawMessage, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || len(fields) != 1 {
+		return nil, false
+	}
+
+	for _, value := range fields {
+		value = bytes.TrimSpace(value)
+		if len(value) > 0 && value[0] == '[' {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// ExtractJSON 은 LLM 응답에서 JSON 값(객체 또는 배열)을 찾아 반환합니다.
+// 코드 블록(```json ... ```), 앞뒤 설명 문장, 스마트 따옴표(“ ”), 닫는 괄호 앞의 쉼표를 허용합니다.
+func ExtractJSON(text string) (string, error) {
+	text = strings.TrimSpace(stripCodeFence(text))
+	if text == "" {
+		return "", fmt.Errorf("empty response")
+	}
+
+	for _, candidate := range []string{text, repairJSON(text)} {
+		if json.Valid([]byte(candidate)) {
+			return candidate, nil
+		}
+
+		// 설명 문장 사이에 들어 있는 JSON 찾기
+		for start := 0; start < len(candidate); start++ {
+			if candidate[start] != '[' && candidate[start] != '{' {
+				continue
+			}
+
+			end := matchingBracket(candidate, start)
+			if end < 0 {
+				continue
+			}
+
+			if value := candidate[start : end+1]; json.Valid([]byte(value)) {
+				return value, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no JSON value found in response")
+}
+
+// stripCodeFence 는 응답 안의 첫 마크다운 코드 블록 내용을 꺼냅니다. 코드 블록이 없으면 그대로 반환합니다.
+func stripCodeFence(text string) string {
+	start := strings.Index(text, "```")
+	if start < 0 {
+		return text
+	}
+
+	body := text[start+3:]
+	// ```json 처럼 언어 표시가 붙은 첫 줄 제거
+	if newline := strings.IndexByte(body, '\n'); newline >= 0 && !strings.ContainsAny(body[:newline], "[{") {
+		body = body[newline+1:]
+	}
+
+	if end := strings.Index(body, "```"); end >= 0 {
+		body = body[:end]
+	}
+
+	return body
+}
+
+// 문자열을 여닫는 데 쓰인 스마트 따옴표
+var smartQuotes = []string{"“", "”", "„"}
+
+// repairJSON 은 LLM 이 자주 만드는 사소한 문법 오류를 고칩니다. (스마트 따옴표, 닫는 괄호 앞의 쉼표)
+// 스마트 따옴표는 문자열을 여닫는 자리에서만 " 로 바꾸고, " 로 연 문자열 안의 스마트 따옴표는 그대로 둡니다.
+func repairJSON(text string) string {
+	var sb strings.Builder
+	inString := false
+	smartString := false // 스마트 따옴표로 연 문자열
+	escaped := false
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		if inString {
+			if smartString && !escaped {
+				if quote := smartQuoteAt(text, i); quote != "" {
+					sb.WriteByte('"')
+					inString = false
+					i += len(quote) - 1
+					continue
+				}
+			}
+
+			sb.WriteByte(c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		if quote := smartQuoteAt(text, i); quote != "" {
+			sb.WriteByte('"')
+			inString, smartString = true, true
+			i += len(quote) - 1
+			continue
+		}
+
+		if c == '"' {
+			inString, smartString = true, false
+		}
+
+		if c == ',' {
+			// 다음 공백이 아닌 문자가 닫는 괄호면 쉼표 제거
+			j := i + 1
+			for j < len(text) && strings.IndexByte(" \t\r\n", text[j]) >= 0 {
+				j++
+			}
+			if j < len(text) && (text[j] == ']' || text[j] == '}') {
+				continue
+			}
+		}
+
+		sb.WriteByte(c)
+	}
+
+	return sb.String()
+}
+
+// smartQuoteAt 은 text 의 i 위치에 있는 스마트 따옴표를 반환합니다. 없으면 빈 문자열입니다.
+func smartQuoteAt(text string, i int) string {
+	for _, quote := range smartQuotes {
+		if strings.HasPrefix(text[i:], quote) {
+			return quote
+		}
+	}
+	return ""
+}
+
+// matchingBracket 은 start 의 여는 괄호와 짝이 맞는 닫는 괄호 위치를 반환합니다. 문자열 안의 괄호는 무시합니다.
+func matchingBracket(text string, start int) int {
+	depth := 0
+	inString := false
+	escaped := false
+
+	for i := start; i < len(text); i++ {
+		c := text[i]
+
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,178 @@
+package llm
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestExtractJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{name: "JSON 만 있는 응답", text: `{"tags": ["a", "b"]}`, want: `{"tags": ["a", "b"]}`},
+		{name: "코드 블록", text: "```json\n[\"a\", \"b\"]\n```", want: `["a", "b"]`},
+		{name: "언어 표시 없는 코드 블록", text: "```\n{\"a\": 1}\n```", want: `{"a": 1}`},
+		{name: "앞뒤 설명 문장", text: `추천 태그입니다: ["a", "b"] 참고하세요.`, want: `["a", "b"]`},
+		{name: "설명 문장 안의 괄호", text: `결과 (아래 참고) {"a": "[x]"} 끝`, want: `{"a": "[x]"}`},
+		{name: "스마트 따옴표", text: `[“a”, “b”]`, want: `["a", "b"]`},
+		{name: "닫는 괄호 앞의 쉼표", text: "{\"tags\": [\"a\", \"b\",],\n}", want: "{\"tags\": [\"a\", \"b\"]\n}"},
+		{name: "문자열 안의 스마트 따옴표는 유지", text: `["그는 “안녕”이라고 말했다"]`, want: `["그는 “안녕”이라고 말했다"]`},
+		{name: "빈 응답", text: "  ", wantErr: true},
+		{name: "JSON 없음", text: "태그를 찾지 못했습니다.", wantErr: true},
+		{name: "닫히지 않은 JSON", text: `{"tags": ["a"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExtractJSON(tt.text)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ExtractJSON(%q) = %q, want error", tt.text, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ExtractJSON(%q) error = %v", tt.text, err)
+			}
+			if got != tt.want {
+				t.Errorf("ExtractJSON(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepairJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "고칠 것 없음", text: `{"a": [1, 2]}`, want: `{"a": [1, 2]}`},
+		{name: "배열 끝 쉼표", text: `[1, 2, ]`, want: `[1, 2 ]`},
+		{name: "객체 끝 쉼표", text: "{\"a\": 1,\n}", want: "{\"a\": 1\n}"},
+		{name: "문자열 안의 쉼표는 유지", text: `["a,]", "b"]`, want: `["a,]", "b"]`},
+		{name: "이스케이프된 따옴표", text: `["a\",]", "b",]`, want: `["a\",]", "b"]`},
+		{name: "스마트 따옴표로 감싼 문자열", text: `{“a”: “b”}`, want: `{"a": "b"}`},
+		{name: "„ 로 여는 문자열", text: `[„a”]`, want: `["a"]`},
+		{name: "문자열 안의 스마트 따옴표는 유지", text: `["“a”"]`, want: `["“a”"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repairJSON(tt.text); got != tt.want {
+				t.Errorf("repairJSON(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSingleArrayField(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		want   string
+		wantOk bool
+	}{
+		{name: "배열 필드 하나", data: `{"tags": ["a", "b"]}`, want: `["a", "b"]`, wantOk: true},
+		{name: "앞뒤 공백", data: `{"tags":   [1]  }`, want: `[1]`, wantOk: true},
+		{name: "필드가 여러 개", data: `{"tags": ["a"], "other": 1}`},
+		{name: "배열이 아닌 필드", data: `{"tags": "a"}`},
+		{name: "배열", data: `["a"]`},
+		{name: "잘못된 JSON", data: `{"tags": [`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := singleArrayField([]byte(tt.data))
+			if ok != tt.wantOk {
+				t.Fatalf("singleArrayField(%q) ok = %v, want %v", tt.data, ok, tt.wantOk)
+			}
+			if ok && string(got) != tt.want {
+				t.Errorf("singleArrayField(%q) = %q, want %q", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func FuzzExtractJSON(f *testing.F) {
+	for _, seed := range []string{
+		`{"tags": ["a", "b"]}`,
+		"```json\n[\"a\"]\n```",
+		`설명 ["a", "b",] 끝`,
+		`[“a”, “b”]`,
+		`{"a": "\"}"}`,
+		`{"a": [`,
+		"```",
+		`„`,
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		got, err := ExtractJSON(text)
+		if err != nil {
+			return
+		}
+		if !json.Valid([]byte(got)) {
+			t.Errorf("ExtractJSON(%q) = %q, not valid JSON", text, got)
+		}
+	})
+}
+
+func FuzzRepairJSON(f *testing.F) {
+	for _, seed := range []string{
+		`{"a": [1, 2,],}`,
+		`["a,]", "b"]`,
+		`["\\", "\"",]`,
+		`{“a”: “b”}`,
+		`["“a”"]`,
+		`„`,
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		repaired := repairJSON(text)
+
+		// 올바른 JSON 은 고친 뒤에도 같은 값이어야 함
+		if !json.Valid([]byte(text)) {
+			return
+		}
+		if !json.Valid([]byte(repaired)) {
+			t.Fatalf("repairJSON(%q) = %q, not valid JSON", text, repaired)
+		}
+
+		var want, got interface{}
+		json.Unmarshal([]byte(text), &want)
+		json.Unmarshal([]byte(repaired), &got)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("repairJSON(%q) = %q, changed the value", text, repaired)
+		}
+	})
+}
+
+func FuzzSingleArrayField(f *testing.F) {
+	for _, seed := range []string{
+		`{"tags": ["a", "b"]}`,
+		`{"tags": "a"}`,
+		`{"a": [], "b": []}`,
+		`["a"]`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		got, ok := singleArrayField(data)
+		if !ok {
+			return
+		}
+		if !json.Valid(got) || got[0] != '[' {
+			t.Errorf("singleArrayField(%q) = %q, not a JSON array", data, got)
+		}
+	})
+}
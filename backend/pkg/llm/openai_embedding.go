@@ -0,0 +1,73 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// OpenAIEmbeddingProvider 는 OpenAI Embeddings API 와 그 호환 서버를 호출합니다.
+type OpenAIEmbeddingProvider struct {
+	client *openai.Client
+	name   string
+	model  string
+}
+
+func NewOpenAIEmbeddingProvider(apiKey, model string) *OpenAIEmbeddingProvider {
+	return &OpenAIEmbeddingProvider{
+		client: openai.NewClient(apiKey),
+		name:   ProviderOpenAI,
+		model:  model,
+	}
+}
+
+// NewLocalEmbeddingProvider 는 OpenAI 호환 API 를 제공하는 로컬 서버용 임베딩 제공자를 생성합니다.
+// 예) Ollama: http://localhost:11434/v1 + nomic-embed-text
+func NewLocalEmbeddingProvider(baseURL, apiKey, model string) *OpenAIEmbeddingProvider {
+	cfg := openai.DefaultConfig(apiKey)
+	cfg.BaseURL = baseURL
+
+	return &OpenAIEmbeddingProvider{
+		client: openai.NewClientWithConfig(cfg),
+		name:   ProviderLocal,
+		model:  model,
+	}
+}
+
+func (p *OpenAIEmbeddingProvider) Name() string {
+	return p.name
+}
+
+func (p *OpenAIEmbeddingProvider) Model() string {
+	return p.model
+}
+
+func (p *OpenAIEmbeddingProvider) Embed(ctx context.Context, texts []string) (*EmbeddingResponse, error) {
+	resp, err := p.client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{
+		Input: texts,
+		Model: openai.EmbeddingModel(p.model),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s embedding API error: %v", p.name, err)
+	}
+
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("%s returned %d embeddings for %d inputs", p.name, len(resp.Data), len(texts))
+	}
+
+	// 응답 순서가 입력 순서와 다를 수 있어 index 로 맞춤
+	vectors := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("%s returned invalid embedding index %d", p.name, d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+
+	return &EmbeddingResponse{
+		Vectors:      vectors,
+		Model:        p.model,
+		PromptTokens: resp.Usage.PromptTokens,
+	}, nil
+}
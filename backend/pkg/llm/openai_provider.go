@@ -0,0 +1,97 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// OpenAIProvider 는 OpenAI Chat Completions API 와 그 호환 서버를 호출합니다.
+type OpenAIProvider struct {
+	client *openai.Client
+	name   string
+	model  string
+	// 로컬 호환 서버는 max_completion_tokens 를 지원하지 않는 경우가 많아 max_tokens 를 사용
+	useMaxTokens bool
+	// response_format(json_schema) 지원 여부
+	structuredOutput bool
+}
+
+func NewOpenAIProvider(apiKey, model string) *OpenAIProvider {
+	return &OpenAIProvider{
+		client:           openai.NewClient(apiKey),
+		name:             ProviderOpenAI,
+		model:            model,
+		structuredOutput: true,
+	}
+}
+
+// NewLocalProvider 는 OpenAI 호환 API 를 제공하는 로컬 서버용 제공자를 생성합니다.
+// 예) Ollama: http://localhost:11434/v1, llama.cpp server: http://localhost:8080/v1
+func NewLocalProvider(baseURL, apiKey, model string) *OpenAIProvider {
+	cfg := openai.DefaultConfig(apiKey)
+	cfg.BaseURL = baseURL
+
+	return &OpenAIProvider{
+		client:       openai.NewClientWithConfig(cfg),
+		name:         ProviderLocal,
+		model:        model,
+		useMaxTokens: true,
+	}
+}
+
+func (p *OpenAIProvider) Name() string {
+	return p.name
+}
+
+func (p *OpenAIProvider) Model() string {
+	return p.model
+}
+
+func (p *OpenAIProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	messages := make([]openai.ChatCompletionMessage, 0, len(req.Messages))
+	for _, m := range req.Messages {
+		messages = append(messages, openai.ChatCompletionMessage{
+			Role:    m.Role,
+			Content: m.Content,
+		})
+	}
+
+	chatReq := openai.ChatCompletionRequest{
+		Model:       p.model,
+		Messages:    messages,
+		Temperature: req.Temperature,
+	}
+	if p.useMaxTokens {
+		chatReq.MaxTokens = req.MaxTokens
+	} else {
+		chatReq.MaxCompletionTokens = req.MaxTokens
+	}
+	if req.ResponseFormat != nil && p.structuredOutput {
+		chatReq.ResponseFormat = &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+				Name:   req.ResponseFormat.Name,
+				Schema: req.ResponseFormat.Schema,
+				Strict: true,
+			},
+		}
+	}
+
+	resp, err := p.client.CreateChatCompletion(ctx, chatReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s API error: %v", p.name, err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("no response from %s", p.name)
+	}
+
+	return &ChatResponse{
+		Content:          resp.Choices[0].Message.Content,
+		Model:            resp.Model,
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"joosum-backend/pkg/config"
+)
+
+// 설정(llmProvider)으로 선택할 수 있는 LLM 제공자
+const (
+	ProviderOpenAI = "openai" // OpenAI API
+	ProviderLocal  = "local"  // OpenAI 호환 로컬 서버 (Ollama, llama.cpp server 등)
+	ProviderFake   = "fake"   // 외부 호출 없이 고정 응답을 주는 테스트용 제공자
+)
+
+const DefaultModel = "gpt-4o-mini"
+
+// 메시지 역할
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+type Message struct {
+	Role    string
+	Content string
+}
+
+type ChatRequest struct {
+	Messages    []Message
+	Temperature float32
+	MaxTokens   int
+	// 설정하면 지원하는 제공자는 structured output 으로 응답 형식을 강제합니다. (ChatJSON 참고)
+	ResponseFormat *ResponseFormat
+}
+
+type ChatResponse struct {
+	Content          string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// LLMProvider 는 채팅 형식의 LLM 호출을 추상화합니다.
+type LLMProvider interface {
+	// Name 은 제공자 이름을 반환합니다. (openai, local, fake)
+	Name() string
+	// Model 은 요청에 사용되는 모델명을 반환합니다.
+	Model() string
+	Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error)
+}
+
+// NewProvider 는 설정값(llmProvider, llmModel)에 따라 LLMProvider 를 생성합니다.
+// llmProvider 가 비어있으면 OpenAI 를 사용합니다.
+func NewProvider() (LLMProvider, error) {
+	return NewProviderByName(config.GetEnvConfig("llmProvider"), config.GetEnvConfig("llmModel"))
+}
+
+// NewProviderByName 은 제공자와 모델을 직접 골라 LLMProvider 를 생성합니다. API 키, 서버 주소는 설정값을 사용합니다.
+// 제공자가 비어있으면 OpenAI, 모델이 비어있으면 DefaultModel 을 사용합니다.
+func NewProviderByName(name string, model string) (LLMProvider, error) {
+	if model == "" {
+		model = DefaultModel
+	}
+
+	switch name {
+	case "", ProviderOpenAI:
+		apiKey := config.GetEnvConfig("openaiApiKey")
+		if apiKey == "" {
+			return nil, fmt.Errorf("OpenAI API key not configured")
+		}
+		return NewOpenAIProvider(apiKey, model), nil
+
+	case ProviderLocal:
+		baseURL := config.GetEnvConfig("llmBaseURL")
+		if baseURL == "" {
+			return nil, fmt.Errorf("llmBaseURL not configured for local LLM provider")
+		}
+		provider := NewLocalProvider(baseURL, config.GetEnvConfig("llmApiKey"), model)
+		// 로컬 서버는 json_schema 응답 형식 지원 여부가 서버/버전마다 달라 설정으로 켭니다
+		provider.structuredOutput = config.GetEnvConfig("llmStructuredOutput") == "true"
+		return provider, nil
+
+	case ProviderFake:
+		return NewFakeProvider(config.GetEnvConfig("llmFakeResponse")), nil
+
+	default:
+		return nil, fmt.Errorf("unknown llmProvider: %s", name)
+	}
+}
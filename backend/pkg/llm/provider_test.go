@@ -0,0 +1,180 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestNewProviderByName(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  string
+		model     string
+		config    map[string]string
+		wantName  string
+		wantModel string
+		wantErr   bool
+	}{
+		{name: "fake", provider: ProviderFake, wantName: ProviderFake, wantModel: ProviderFake},
+		{name: "openai 기본 모델", provider: "", config: map[string]string{"openaiApiKey": "test-key"}, wantName: ProviderOpenAI, wantModel: DefaultModel},
+		{name: "openai 모델 지정", provider: ProviderOpenAI, model: "gpt-4o", config: map[string]string{"openaiApiKey": "test-key"}, wantName: ProviderOpenAI, wantModel: "gpt-4o"},
+		{name: "openai 키 없음", provider: ProviderOpenAI, wantErr: true},
+		{name: "local", provider: ProviderLocal, model: "llama3", config: map[string]string{"llmBaseURL": "http://localhost:11434/v1"}, wantName: ProviderLocal, wantModel: "llama3"},
+		{name: "local 주소 없음", provider: ProviderLocal, wantErr: true},
+		{name: "알 수 없는 제공자", provider: "anthropic", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			for key, value := range tt.config {
+				viper.Set(key, value)
+			}
+
+			provider, err := NewProviderByName(tt.provider, tt.model)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("err = nil, want error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if provider.Name() != tt.wantName || provider.Model() != tt.wantModel {
+				t.Errorf("provider = %s/%s, want %s/%s", provider.Name(), provider.Model(), tt.wantName, tt.wantModel)
+			}
+		})
+	}
+}
+
+func TestLocalProviderChat(t *testing.T) {
+	var got map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "1", "object": "chat.completion", "model": "llama3",
+			"choices": [{"index": 0, "message": {"role": "assistant", "content": "[\"React\"]"}, "finish_reason": "stop"}],
+			"usage": {"prompt_tokens": 12, "completion_tokens": 3, "total_tokens": 15}}`))
+	}))
+	defer server.Close()
+
+	provider := NewLocalProvider(server.URL+"/v1", "", "llama3")
+	resp, err := provider.Chat(context.Background(), ChatRequest{
+		Messages:       []Message{{Role: RoleUser, Content: "태그 추천"}},
+		MaxTokens:      200,
+		ResponseFormat: &ResponseFormat{Name: "ai_tags", Schema: json.RawMessage(`{"type": "object"}`)},
+	})
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+
+	want := &ChatResponse{Content: `["React"]`, Model: "llama3", PromptTokens: 12, CompletionTokens: 3}
+	if !reflect.DeepEqual(resp, want) {
+		t.Errorf("resp = %+v, want %+v", resp, want)
+	}
+
+	// 로컬 서버는 max_completion_tokens 대신 max_tokens 를 쓰고, structured output 은 설정(llmStructuredOutput)으로 켤 때만 보냄
+	if got["model"] != "llama3" || got["max_tokens"] != float64(200) {
+		t.Errorf("request = %v", got)
+	}
+	if _, ok := got["max_completion_tokens"]; ok {
+		t.Errorf("max_completion_tokens 를 보냄: %v", got["max_completion_tokens"])
+	}
+	if _, ok := got["response_format"]; ok {
+		t.Errorf("response_format 을 보냄: %v", got["response_format"])
+	}
+}
+
+func TestFakeProvider(t *testing.T) {
+	provider := NewFakeProvider("")
+	resp, err := provider.Chat(context.Background(), ChatRequest{Messages: []Message{{Role: RoleUser, Content: "태그 추천"}}})
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	// 응답을 정하지 않으면 빈 배열
+	if resp.Content != "[]" || resp.Model != ProviderFake {
+		t.Errorf("resp = %+v", resp)
+	}
+
+	provider.Respond = func(req ChatRequest) (string, error) {
+		return "", errors.New("unavailable")
+	}
+	if _, err := provider.Chat(context.Background(), ChatRequest{}); err == nil {
+		t.Errorf("err = nil, want error")
+	}
+
+	// 실패한 요청도 기록
+	if requests := provider.Requests(); len(requests) != 2 || requests[0].Messages[0].Content != "태그 추천" {
+		t.Errorf("requests = %+v", requests)
+	}
+}
+
+func TestChatJSON(t *testing.T) {
+	tests := []struct {
+		name         string
+		responses    []string
+		want         []string
+		wantErr      bool
+		wantRequests int
+	}{
+		{name: "JSON 배열", responses: []string{`["a", "b"]`}, want: []string{"a", "b"}, wantRequests: 1},
+		{name: "배열 필드 하나인 객체", responses: []string{`{"tags": ["a"]}`}, want: []string{"a"}, wantRequests: 1},
+		{name: "설명이 섞인 응답", responses: []string{"결과: [“a”, “b”,]"}, want: []string{"a", "b"}, wantRequests: 1},
+		{name: "한 번 다시 요청", responses: []string{"a, b", `["a", "b"]`}, want: []string{"a", "b"}, wantRequests: 2},
+		{name: "다시 요청해도 실패", responses: []string{"a, b", "a, b"}, wantErr: true, wantRequests: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			provider := &FakeProvider{Respond: func(req ChatRequest) (string, error) {
+				response := tt.responses[calls]
+				calls++
+				return response, nil
+			}}
+
+			var got []string
+			_, err := ChatJSON(context.Background(), provider, ChatRequest{Messages: []Message{{Role: RoleUser, Content: "태그"}}}, &got)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %v, want %v", got, tt.want)
+			}
+
+			requests := provider.Requests()
+			if len(requests) != tt.wantRequests {
+				t.Fatalf("요청 수 = %d, want %d", len(requests), tt.wantRequests)
+			}
+			// 다시 요청할 때는 이전 응답과 오류 안내를 붙임
+			if tt.wantRequests == 2 && len(requests[1].Messages) != 3 {
+				t.Errorf("다시 요청한 메시지 = %+v", requests[1].Messages)
+			}
+		})
+	}
+}
+
+func TestChatJSONProviderError(t *testing.T) {
+	provider := &FakeProvider{Respond: func(req ChatRequest) (string, error) {
+		return "", errors.New("unavailable")
+	}}
+
+	var got []string
+	if _, err := ChatJSON(context.Background(), provider, ChatRequest{}, &got); err == nil {
+		t.Errorf("err = nil, want error")
+	}
+}
@@ -0,0 +1,24 @@
+package llm
+
+import "context"
+
+// usageRecordingProvider 는 성공한 호출마다 응답을 record 로 넘깁니다.
+type usageRecordingProvider struct {
+	LLMProvider
+	record func(resp *ChatResponse)
+}
+
+// WithUsageRecorder 는 호출마다 토큰 사용량을 기록할 수 있도록 제공자를 감쌉니다.
+func WithUsageRecorder(provider LLMProvider, record func(resp *ChatResponse)) LLMProvider {
+	return &usageRecordingProvider{LLMProvider: provider, record: record}
+}
+
+func (p *usageRecordingProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	resp, err := p.LLMProvider.Chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	p.record(resp)
+	return resp, nil
+}
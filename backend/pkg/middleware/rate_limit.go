@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"joosum-backend/app/user"
+	"joosum-backend/pkg/config"
+	"joosum-backend/pkg/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitRule 은 window 동안 허용하는 요청 수입니다.
+// LimitKey 설정값이 있으면 허용 횟수로 사용하고, 없으면 DefaultLimit 을 사용한다.
+type RateLimitRule struct {
+	LimitKey     string
+	DefaultLimit int
+	Window       time.Duration
+}
+
+func (r RateLimitRule) limit() int {
+	value := config.GetEnvConfig(r.LimitKey)
+	if value == "" {
+		return r.DefaultLimit
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("잘못된 %s 설정(%s), 기본값 %d 사용", r.LimitKey, value, r.DefaultLimit)
+		return r.DefaultLimit
+	}
+	return parsed
+}
+
+// UserRateLimit 는 로그인한 사용자별로 요청 수를 제한한다.
+// 여러 rule(분당, 하루 등)을 한 번에 검사하고 모두 통과한 요청만 기록하므로, 한 제한에 막힌 요청이 다른 제한의 횟수를 쓰지 않는다.
+// 요청 기록은 DB 에 남기므로 서버 인스턴스가 여러 개여도 사용자별로 함께 제한한다.
+// SetUserData 뒤에 등록해야 한다.
+func UserRateLimit(name string, rules ...RateLimitRule) gin.HandlerFunc {
+	var limits []util.RateLimit
+	for _, rule := range rules {
+		limits = append(limits, util.RateLimit{Limit: rule.limit(), Window: rule.Window})
+	}
+
+	limiter := util.NewRateLimiter(name, limits...)
+
+	return func(c *gin.Context) {
+		currentUser, exists := c.Get("user")
+		if !exists {
+			util.SendError(c, http.StatusUnauthorized, util.CodeMissingAuthorization)
+			c.Abort()
+			return
+		}
+
+		allowed, retryAfter := limiter.Allow(currentUser.(*user.User).UserId)
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			util.SendError(c, http.StatusTooManyRequests, util.CodeTooManyRequests)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,198 @@
+package push
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"joosum-backend/pkg/util"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	apnsProductionEndpoint = "https://api.push.apple.com"
+	apnsSandboxEndpoint    = "https://api.sandbox.push.apple.com"
+	// APNs 는 1시간보다 오래된 토큰을 거부하고, 20분보다 자주 새로 만들면 TooManyProviderTokenUpdates 를 반환합니다.
+	apnsTokenRefresh = 50 * time.Minute
+)
+
+// APNs 오류 reason (https://developer.apple.com/documentation/usernotifications/handling-notification-responses-from-apns)
+const (
+	ApnsErrBadDeviceToken         = "BadDeviceToken"
+	ApnsErrUnregistered           = "Unregistered"
+	ApnsErrDeviceTokenNotForTopic = "DeviceTokenNotForTopic"
+	ApnsErrTooManyRequests        = "TooManyRequests"
+	ApnsErrInternalServerError    = "InternalServerError"
+	ApnsErrServiceUnavailable     = "ServiceUnavailable"
+)
+
+// APNsConfig 는 APNs 토큰 인증(.p8 키) 설정입니다.
+type APNsConfig struct {
+	// Apple Developer 에서 받은 .p8 키 파일 (apnsKeyFile)
+	KeyFile string
+	// 키 ID (apnsKeyId)
+	KeyId string
+	// 팀 ID (apnsTeamId)
+	TeamId string
+	// 앱 번들 ID (apnsTopic)
+	Topic string
+	// 개발 빌드로 보낼 때 true (apnsSandbox)
+	Sandbox bool
+	// 비우면 Sandbox 에 따라 운영/개발 주소를 사용합니다. (apnsEndpoint)
+	Endpoint string
+	Timeout  time.Duration
+}
+
+// APNsSender 는 FCM 을 거치지 않고 APNs 로 직접 푸시를 보냅니다.
+// iOS 앱이 FCM 등록 토큰이 아닌 APNs 디바이스 토큰을 등록(POST /settings/device)해야 합니다.
+type APNsSender struct {
+	client *resty.Client
+	url    string
+	topic  string
+	keyId  string
+	teamId string
+	key    *ecdsa.PrivateKey
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+func NewAPNsSender(cfg APNsConfig) (*APNsSender, error) {
+	if cfg.KeyFile == "" || cfg.KeyId == "" || cfg.TeamId == "" || cfg.Topic == "" {
+		return nil, fmt.Errorf("apns: apnsKeyFile, apnsKeyId, apnsTeamId, apnsTopic are required")
+	}
+
+	key, err := util.LoadPrivateKey(cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("apns: failed to load key file at %s: %v", cfg.KeyFile, err)
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = apnsProductionEndpoint
+		if cfg.Sandbox {
+			endpoint = apnsSandboxEndpoint
+		}
+	}
+
+	// APNs 는 HTTP/2 만 받습니다. resty 의 기본 Transport 는 TLS 에서 HTTP/2 를 사용합니다.
+	client := resty.New()
+	client.SetTimeout(cfg.Timeout)
+
+	return &APNsSender{
+		client: client,
+		url:    endpoint + "/3/device/",
+		topic:  cfg.Topic,
+		keyId:  cfg.KeyId,
+		teamId: cfg.TeamId,
+		key:    key,
+	}, nil
+}
+
+func (s *APNsSender) Name() string {
+	return ProviderAPNs
+}
+
+// providerToken 은 APNs 인증 토큰(ES256 JWT)을 반환합니다. apnsTokenRefresh 마다 새로 만듭니다.
+func (s *APNsSender) providerToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Since(s.issuedAt) < apnsTokenRefresh {
+		return s.token, nil
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": s.teamId,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = s.keyId
+
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("apns: failed to sign provider token: %v", err)
+	}
+
+	s.token = signed
+	s.issuedAt = now
+	return signed, nil
+}
+
+func (s *APNsSender) Send(ctx context.Context, msg Message) error {
+	providerToken, err := s.providerToken()
+	if err != nil {
+		return err
+	}
+
+	// 알림 내용은 aps 에, 앱으로 넘기는 값은 최상위 키로 보냅니다.
+	payload := map[string]interface{}{
+		"aps": map[string]interface{}{
+			"alert": map[string]string{
+				"title": msg.Title,
+				"body":  msg.Body,
+			},
+			"sound": "default",
+		},
+	}
+	for k, v := range msg.Data {
+		if k != "aps" {
+			payload[k] = v
+		}
+	}
+
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetHeader("authorization", "bearer "+providerToken).
+		SetHeader("apns-topic", s.topic).
+		SetHeader("apns-push-type", "alert").
+		SetHeader("apns-priority", "10").
+		SetBody(payload).
+		Post(s.url + msg.Token)
+	if err != nil {
+		return &Error{Provider: ProviderAPNs, Reason: ReasonNetwork, Message: err.Error(), Retryable: true}
+	}
+	if resp.IsSuccess() {
+		return nil
+	}
+
+	pushErr := parseAPNsError(resp.StatusCode(), resp.Body())
+	pushErr.RetryAfter = parseRetryAfter(resp.Header().Get("Retry-After"))
+	return pushErr
+}
+
+// parseAPNsError 는 APNs 오류 응답 본문({"reason": "BadDeviceToken"})을 Error 로 바꿉니다.
+func parseAPNsError(statusCode int, body []byte) *Error {
+	pushErr := &Error{Provider: ProviderAPNs, StatusCode: statusCode, Message: string(body)}
+
+	var parsed struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil && parsed.Reason != "" {
+		pushErr.Reason = parsed.Reason
+	} else {
+		pushErr.Reason = http.StatusText(statusCode)
+	}
+
+	// 토큰은 앱이 삭제된 경우(410 Unregistered)에만 지웁니다.
+	// BadDeviceToken 은 apnsSandbox 설정이 앱 빌드(개발/운영)와 다를 때도 모든 토큰에서 나고,
+	// DeviceTokenNotForTopic 은 apnsTopic 설정이 잘못돼도 모든 토큰에서 나므로 설정 오류로 보고 토큰을 지우지 않습니다.
+	switch pushErr.Reason {
+	case ApnsErrUnregistered:
+		pushErr.InvalidToken = true
+	case ApnsErrBadDeviceToken, ApnsErrDeviceTokenNotForTopic:
+		pushErr.ConfigError = true
+	}
+	if statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError {
+		pushErr.Retryable = true
+	}
+
+	return pushErr
+}
@@ -0,0 +1,58 @@
+package push
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseAPNsError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   Error
+	}{
+		{
+			name:   "Unregistered 는 토큰 삭제",
+			status: http.StatusGone,
+			body:   `{"reason": "Unregistered", "timestamp": 1700000000000}`,
+			want:   Error{Reason: ApnsErrUnregistered, InvalidToken: true},
+		},
+		{
+			name:   "BadDeviceToken 은 개발/운영 환경이 달라도 나므로 토큰 유지",
+			status: http.StatusBadRequest,
+			body:   `{"reason": "BadDeviceToken"}`,
+			want:   Error{Reason: ApnsErrBadDeviceToken, ConfigError: true},
+		},
+		{
+			name:   "DeviceTokenNotForTopic 은 설정 오류",
+			status: http.StatusBadRequest,
+			body:   `{"reason": "DeviceTokenNotForTopic"}`,
+			want:   Error{Reason: ApnsErrDeviceTokenNotForTopic, ConfigError: true},
+		},
+		{
+			name:   "TooManyRequests 는 재시도",
+			status: http.StatusTooManyRequests,
+			body:   `{"reason": "TooManyRequests"}`,
+			want:   Error{Reason: ApnsErrTooManyRequests, Retryable: true},
+		},
+		{
+			name:   "본문이 없으면 상태 코드로",
+			status: http.StatusServiceUnavailable,
+			want:   Error{Reason: "Service Unavailable", Retryable: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseAPNsError(tt.status, []byte(tt.body))
+			if got.Provider != ProviderAPNs || got.StatusCode != tt.status {
+				t.Errorf("Provider, StatusCode = %s, %d, want %s, %d", got.Provider, got.StatusCode, ProviderAPNs, tt.status)
+			}
+			if got.Reason != tt.want.Reason || got.InvalidToken != tt.want.InvalidToken ||
+				got.ConfigError != tt.want.ConfigError || got.Retryable != tt.want.Retryable {
+				t.Errorf("parseAPNsError = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
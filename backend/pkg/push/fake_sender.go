@@ -0,0 +1,50 @@
+package push
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeSender 는 외부 호출 없이 보낸 메시지를 기록하는 제공자입니다.
+// 테스트와 로컬 개발에서 사용하며, 알림 작업을 처음부터 끝까지 실행해 볼 수 있습니다.
+type FakeSender struct {
+	// Fail 이 설정되어 있으면 메시지마다 호출하고, 오류를 반환하면 발송 실패로 처리합니다.
+	Fail func(msg Message) error
+
+	mu   sync.Mutex
+	sent []Message
+}
+
+func NewFakeSender() *FakeSender {
+	return &FakeSender{}
+}
+
+func (s *FakeSender) Name() string {
+	return ProviderFake
+}
+
+func (s *FakeSender) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if s.Fail != nil {
+		if err := s.Fail(msg); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	s.sent = append(s.sent, msg)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Sent 는 지금까지 발송에 성공한 메시지 목록을 반환합니다.
+func (s *FakeSender) Sent() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message(nil), s.sent...)
+}
@@ -0,0 +1,194 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	firebaseScope      = "https://www.googleapis.com/auth/firebase.messaging"
+	defaultFcmEndpoint = "https://fcm.googleapis.com"
+)
+
+// FCM v1 오류 코드 (https://firebase.google.com/docs/reference/fcm/rest/v1/ErrorCode)
+const (
+	FcmErrUnspecified      = "UNSPECIFIED_ERROR"
+	FcmErrInvalidArgument  = "INVALID_ARGUMENT"
+	FcmErrUnregistered     = "UNREGISTERED"
+	FcmErrSenderIdMismatch = "SENDER_ID_MISMATCH"
+	FcmErrQuotaExceeded    = "QUOTA_EXCEEDED"
+	FcmErrUnavailable      = "UNAVAILABLE"
+	FcmErrInternal         = "INTERNAL"
+	FcmErrThirdPartyAuth   = "THIRD_PARTY_AUTH_ERROR"
+	fcmErrorDetailType     = "type.googleapis.com/google.firebase.fcm.v1.FcmError"
+	fcmBadRequestType      = "type.googleapis.com/google.rpc.BadRequest"
+	// INVALID_ARGUMENT 가 토큰 때문일 때 fieldViolations 의 field
+	fcmTokenField = "message.token"
+)
+
+// FCMSender 는 FCM v1 API 로 푸시를 보냅니다.
+type FCMSender struct {
+	client      *resty.Client
+	url         string
+	tokenSource oauth2.TokenSource
+}
+
+// NewFCMSender 는 서비스 계정 키 파일로 FCMSender 를 만듭니다.
+// endpoint 를 비우면 https://fcm.googleapis.com 을 사용합니다. (로컬 가짜 FCM 서버로 확인할 때 fcmEndpoint 로 바꿉니다)
+func NewFCMSender(credentialsFile, projectId, endpoint string, timeout time.Duration) (*FCMSender, error) {
+	jsonKey, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("fcm: failed to read credentials file at: %s", credentialsFile)
+	}
+	cfg, err := google.JWTConfigFromJSON(jsonKey, firebaseScope)
+	if err != nil {
+		return nil, fmt.Errorf("fcm: failed to get JWT config for the firebase.messaging scope: %v", err)
+	}
+
+	if endpoint == "" {
+		endpoint = defaultFcmEndpoint
+	}
+
+	client := resty.New()
+	client.SetTimeout(timeout)
+
+	return &FCMSender{
+		client: client,
+		url:    endpoint + "/v1/projects/" + projectId + "/messages:send",
+		// 액세스 토큰은 만료되기 전까지 재사용하고, 만료되면 다시 발급합니다.
+		tokenSource: cfg.TokenSource(context.Background()),
+	}, nil
+}
+
+func (s *FCMSender) Name() string {
+	return ProviderFCM
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+func (s *FCMSender) Send(ctx context.Context, msg Message) error {
+	token, err := s.tokenSource.Token()
+	if err != nil {
+		return fmt.Errorf("fcm: failed to generate Bearer token: %v", err)
+	}
+
+	body := map[string]fcmMessage{
+		"message": {
+			Token:        msg.Token,
+			Notification: fcmNotification{Title: msg.Title, Body: msg.Body},
+			Data:         msg.Data,
+		},
+	}
+
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetAuthToken(token.AccessToken).
+		SetBody(body).
+		Post(s.url)
+	if err != nil {
+		return &Error{Provider: ProviderFCM, Reason: ReasonNetwork, Message: err.Error(), Retryable: true}
+	}
+	if resp.IsSuccess() {
+		return nil
+	}
+
+	pushErr := parseFcmError(resp.StatusCode(), resp.Body())
+	pushErr.RetryAfter = parseRetryAfter(resp.Header().Get("Retry-After"))
+	return pushErr
+}
+
+type fcmErrorBody struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			Type            string `json:"@type"`
+			ErrorCode       string `json:"errorCode"`
+			FieldViolations []struct {
+				Field       string `json:"field"`
+				Description string `json:"description"`
+			} `json:"fieldViolations"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// parseFcmError 는 FCM 오류 응답 본문을 Error 로 바꿉니다.
+//
+//	{"error": {"code": 404, "message": "...", "status": "NOT_FOUND",
+//	  "details": [{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": "UNREGISTERED"}]}}
+//
+// 토큰 삭제로 이어지는 코드는 details 의 errorCode 로만 정하고, errorCode 가 없으면 HTTP 상태 코드로 재시도 여부만 구분합니다.
+// (잘못된 projectId 의 404 같은 오류로 모든 토큰을 지우지 않기 위해)
+// 토큰은 UNREGISTERED 이거나, INVALID_ARGUMENT 의 BadRequest 상세가 message.token 을 가리킬 때만 지웁니다.
+// 제목이 너무 긴 알림 같은 잘못된 메시지나 SENDER_ID_MISMATCH(다른 프로젝트의 토큰, 설정 오류)로는 토큰을 지우지 않습니다.
+func parseFcmError(statusCode int, body []byte) *Error {
+	pushErr := &Error{Provider: ProviderFCM, StatusCode: statusCode, Message: string(body)}
+
+	tokenViolation := false
+	var parsed fcmErrorBody
+	if err := json.Unmarshal(body, &parsed); err == nil && parsed.Error.Status != "" {
+		pushErr.Message = parsed.Error.Status + ": " + parsed.Error.Message
+		for _, detail := range parsed.Error.Details {
+			switch detail.Type {
+			case fcmErrorDetailType:
+				if detail.ErrorCode != "" && pushErr.Reason == "" {
+					pushErr.Reason = detail.ErrorCode
+					pushErr.Message = parsed.Error.Message
+				}
+			case fcmBadRequestType:
+				for _, violation := range detail.FieldViolations {
+					if violation.Field == fcmTokenField {
+						tokenViolation = true
+					}
+				}
+			}
+		}
+	}
+
+	if pushErr.Reason == "" {
+		switch {
+		case statusCode == http.StatusTooManyRequests:
+			pushErr.Reason = FcmErrQuotaExceeded
+		case statusCode == http.StatusServiceUnavailable:
+			pushErr.Reason = FcmErrUnavailable
+		case statusCode >= http.StatusInternalServerError:
+			pushErr.Reason = FcmErrInternal
+		default:
+			pushErr.Reason = FcmErrUnspecified
+		}
+	}
+
+	switch pushErr.Reason {
+	case FcmErrUnregistered:
+		pushErr.InvalidToken = true
+	case FcmErrInvalidArgument:
+		pushErr.InvalidToken = tokenViolation
+	case FcmErrSenderIdMismatch, FcmErrThirdPartyAuth:
+		pushErr.ConfigError = true
+	case FcmErrQuotaExceeded, FcmErrUnavailable, FcmErrInternal:
+		pushErr.Retryable = true
+	}
+	if statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError {
+		pushErr.Retryable = true
+	}
+
+	return pushErr
+}
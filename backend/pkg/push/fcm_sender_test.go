@@ -0,0 +1,159 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/oauth2"
+)
+
+// newTestFCMSender 는 httptest 서버로 보내는 FCMSender 를 만듭니다. (서비스 계정 키 없이 고정 액세스 토큰 사용)
+func newTestFCMSender(serverURL string, timeout time.Duration) *FCMSender {
+	return &FCMSender{
+		client:      resty.New().SetTimeout(timeout),
+		url:         serverURL + "/v1/projects/test/messages:send",
+		tokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+	}
+}
+
+func TestFCMSenderSend(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		body       string
+		// 성공이면 wantErr 는 nil
+		wantErr *Error
+	}{
+		{
+			name:   "성공",
+			status: http.StatusOK,
+			body:   `{"name": "projects/test/messages/1"}`,
+		},
+		{
+			name:    "UNREGISTERED 는 토큰 삭제",
+			status:  http.StatusNotFound,
+			body:    `{"error": {"code": 404, "message": "Requested entity was not found.", "status": "NOT_FOUND", "details": [{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": "UNREGISTERED"}]}}`,
+			wantErr: &Error{Reason: FcmErrUnregistered, InvalidToken: true},
+		},
+		{
+			name:    "토큰을 가리키는 INVALID_ARGUMENT 는 토큰 삭제",
+			status:  http.StatusBadRequest,
+			body:    `{"error": {"code": 400, "message": "The registration token is not a valid FCM registration token", "status": "INVALID_ARGUMENT", "details": [{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": "INVALID_ARGUMENT"}, {"@type": "type.googleapis.com/google.rpc.BadRequest", "fieldViolations": [{"field": "message.token", "description": "Invalid registration token"}]}]}}`,
+			wantErr: &Error{Reason: FcmErrInvalidArgument, InvalidToken: true},
+		},
+		{
+			name:    "메시지가 잘못된 INVALID_ARGUMENT 는 토큰 유지",
+			status:  http.StatusBadRequest,
+			body:    `{"error": {"code": 400, "message": "Invalid value", "status": "INVALID_ARGUMENT", "details": [{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": "INVALID_ARGUMENT"}, {"@type": "type.googleapis.com/google.rpc.BadRequest", "fieldViolations": [{"field": "message.notification.title", "description": "too long"}]}]}}`,
+			wantErr: &Error{Reason: FcmErrInvalidArgument},
+		},
+		{
+			name:    "SENDER_ID_MISMATCH 는 설정 오류",
+			status:  http.StatusForbidden,
+			body:    `{"error": {"code": 403, "message": "SenderId mismatch", "status": "PERMISSION_DENIED", "details": [{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": "SENDER_ID_MISMATCH"}]}}`,
+			wantErr: &Error{Reason: FcmErrSenderIdMismatch, ConfigError: true},
+		},
+		{
+			name:       "429 는 Retry-After 와 함께 재시도",
+			status:     http.StatusTooManyRequests,
+			retryAfter: "7",
+			body:       `{"error": {"code": 429, "message": "Quota exceeded", "status": "RESOURCE_EXHAUSTED", "details": [{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": "QUOTA_EXCEEDED"}]}}`,
+			wantErr:    &Error{Reason: FcmErrQuotaExceeded, Retryable: true, RetryAfter: 7 * time.Second},
+		},
+		{
+			name:    "본문 없는 503 은 재시도",
+			status:  http.StatusServiceUnavailable,
+			wantErr: &Error{Reason: FcmErrUnavailable, Retryable: true},
+		},
+		{
+			name:    "errorCode 없는 404 (잘못된 projectId) 는 토큰 유지",
+			status:  http.StatusNotFound,
+			body:    `{"error": {"code": 404, "message": "Requested entity was not found.", "status": "NOT_FOUND"}}`,
+			wantErr: &Error{Reason: FcmErrUnspecified},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+					t.Errorf("Authorization = %q", got)
+				}
+
+				var req struct {
+					Message fcmMessage `json:"message"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Errorf("요청 본문 파싱 실패: %v", err)
+				}
+				if req.Message.Token != "device-token" || req.Message.Data["type"] != "unread" {
+					t.Errorf("요청 메시지 = %+v", req.Message)
+				}
+
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			err := newTestFCMSender(server.URL, time.Second).Send(context.Background(), Message{
+				Token: "device-token",
+				Title: "title",
+				Body:  "body",
+				Data:  map[string]string{"type": "unread"},
+			})
+
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("err = %v, want nil", err)
+				}
+				return
+			}
+
+			var pushErr *Error
+			if !errors.As(err, &pushErr) {
+				t.Fatalf("err = %v, want *Error", err)
+			}
+			if pushErr.StatusCode != tt.status ||
+				pushErr.Reason != tt.wantErr.Reason ||
+				pushErr.InvalidToken != tt.wantErr.InvalidToken ||
+				pushErr.ConfigError != tt.wantErr.ConfigError ||
+				pushErr.Retryable != tt.wantErr.Retryable ||
+				pushErr.RetryAfter != tt.wantErr.RetryAfter {
+				t.Errorf("err = %+v, want %+v", pushErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFCMSenderTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	start := time.Now()
+	err := newTestFCMSender(server.URL, 100*time.Millisecond).Send(context.Background(), Message{Token: "device-token"})
+
+	var pushErr *Error
+	if !errors.As(err, &pushErr) || pushErr.Reason != ReasonNetwork || !pushErr.Retryable {
+		t.Fatalf("err = %v, want retryable %s", err, ReasonNetwork)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("요청 제한 시간이 지나도 기다림: %v", elapsed)
+	}
+}
@@ -0,0 +1,134 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+// retrySender 는 다른 PushSender 의 요청 수를 제한하고 재시도 가능한 오류를 다시 보냅니다.
+type retrySender struct {
+	PushSender
+	limiter    *rateLimiter
+	maxRetries int
+}
+
+// WithRetry 는 sender 의 요청을 초당 ratePerSecond 번으로 제한하고(0 이면 제한 없음),
+// 재시도 가능한 오류(429, 5xx, 네트워크 오류)는 지수 백오프(Retry-After 가 있으면 그 시간)로 maxRetries 번까지 다시 보냅니다.
+func WithRetry(sender PushSender, ratePerSecond int, maxRetries int) PushSender {
+	return &retrySender{PushSender: sender, limiter: newRateLimiter(ratePerSecond), maxRetries: maxRetries}
+}
+
+func (s *retrySender) Send(ctx context.Context, msg Message) error {
+	var err error
+	for attempt := 0; attempt <= s.maxRetries; attempt++ {
+		if waitErr := s.limiter.Wait(ctx); waitErr != nil {
+			return waitErr
+		}
+
+		err = s.PushSender.Send(ctx, msg)
+		if err == nil {
+			return nil
+		}
+
+		var pushErr *Error
+		if !errors.As(err, &pushErr) || !pushErr.Retryable {
+			return err
+		}
+		if attempt == s.maxRetries || ctx.Err() != nil {
+			break
+		}
+
+		delay := backoff(attempt)
+		if pushErr.RetryAfter > delay {
+			delay = pushErr.RetryAfter
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return err
+}
+
+// backoff 는 attempt 번째 재시도까지 기다릴 시간입니다. (0.5초, 1초, 2초 ... 최대 30초, ±20% 지터)
+func backoff(attempt int) time.Duration {
+	delay := retryBaseDelay << uint(attempt)
+	if delay <= 0 || delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(delay)/5*2+1)) - delay/5
+	return delay + jitter
+}
+
+// parseRetryAfter 는 초 단위 Retry-After 헤더를 읽습니다. 없거나 읽을 수 없으면 0 입니다.
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	delay := time.Duration(seconds) * time.Second
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+// rateLimiter 는 요청 사이 간격을 일정하게 유지해 초당 요청 수를 제한합니다.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(perSecond int) *rateLimiter {
+	if perSecond <= 0 {
+		return &rateLimiter{}
+	}
+	return &rateLimiter{interval: time.Second / time.Duration(perSecond)}
+}
+
+// Wait 는 다음 요청을 보낼 수 있을 때까지 기다립니다.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l.interval == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,150 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newSequenceServer 는 요청마다 statuses 의 상태 코드를 차례로 응답하고, 끝나면 200 을 응답하는 서버입니다.
+func newSequenceServer(t *testing.T, statuses []int, retryAfter string) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		if n > len(statuses) {
+			w.Write([]byte(`{"name": "projects/test/messages/1"}`))
+			return
+		}
+
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(statuses[n-1])
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func TestWithRetryRetryAfter(t *testing.T) {
+	server, calls := newSequenceServer(t, []int{http.StatusTooManyRequests}, "1")
+	sender := WithRetry(newTestFCMSender(server.URL, time.Second), 0, 3)
+
+	start := time.Now()
+	if err := sender.Send(context.Background(), Message{Token: "device-token"}); err != nil {
+		t.Fatalf("err = %v, want nil", err)
+	}
+
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("요청 수 = %d, want 2", got)
+	}
+	// 첫 백오프(0.5초 내외)보다 긴 Retry-After(1초)만큼 기다림
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Retry-After 전에 재시도: %v", elapsed)
+	}
+}
+
+func TestWithRetryBackoff(t *testing.T) {
+	server, calls := newSequenceServer(t, []int{http.StatusServiceUnavailable, http.StatusInternalServerError}, "")
+	sender := WithRetry(newTestFCMSender(server.URL, time.Second), 0, 3)
+
+	start := time.Now()
+	if err := sender.Send(context.Background(), Message{Token: "device-token"}); err != nil {
+		t.Fatalf("err = %v, want nil", err)
+	}
+
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("요청 수 = %d, want 3", got)
+	}
+	// 0.5초, 1초 백오프 (±20% 지터)
+	if elapsed := time.Since(start); elapsed < 1200*time.Millisecond {
+		t.Errorf("지수 백오프 없이 재시도: %v", elapsed)
+	}
+}
+
+func TestWithRetryGivesUp(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		retries   int
+		wantCalls int32
+	}{
+		{
+			name:      "재시도 횟수를 넘으면 마지막 오류 반환",
+			statuses:  []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			retries:   1,
+			wantCalls: 2,
+		},
+		{
+			name:      "재시도할 수 없는 오류는 바로 반환",
+			statuses:  []int{http.StatusBadRequest},
+			retries:   3,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newSequenceServer(t, tt.statuses, "")
+			sender := WithRetry(newTestFCMSender(server.URL, time.Second), 0, tt.retries)
+
+			err := sender.Send(context.Background(), Message{Token: "device-token"})
+
+			var pushErr *Error
+			if !errors.As(err, &pushErr) || pushErr.StatusCode != tt.statuses[0] {
+				t.Errorf("err = %v, want status %d", err, tt.statuses[0])
+			}
+			if got := atomic.LoadInt32(calls); got != tt.wantCalls {
+				t.Errorf("요청 수 = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWithRetryContextDeadline(t *testing.T) {
+	server, calls := newSequenceServer(t, []int{http.StatusTooManyRequests, http.StatusTooManyRequests}, "30")
+	sender := WithRetry(newTestFCMSender(server.URL, time.Second), 0, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := sender.Send(ctx, Message{Token: "device-token"})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("요청 수 = %d, want 1", got)
+	}
+	// Retry-After(30초)를 기다리지 않고 실행 제한 시간에 멈춤
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("실행 제한 시간이 지나도 기다림: %v", elapsed)
+	}
+}
+
+func TestWithRetryRateLimit(t *testing.T) {
+	fake := NewFakeSender()
+	sender := WithRetry(fake, 20, 0)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := sender.Send(context.Background(), Message{Token: "device-token"}); err != nil {
+			t.Fatalf("err = %v", err)
+		}
+	}
+
+	if got := len(fake.Sent()); got != 5 {
+		t.Errorf("보낸 메시지 수 = %d, want 5", got)
+	}
+	// 초당 20번이면 요청 사이 간격은 50ms. 첫 요청은 바로 보내므로 최소 200ms
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("초당 요청 수 제한 없이 보냄: %v", elapsed)
+	}
+}
@@ -0,0 +1,174 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"joosum-backend/pkg/config"
+)
+
+// 설정(pushProvider, pushProviders.<플랫폼>)으로 선택할 수 있는 푸시 제공자
+const (
+	ProviderFCM  = "fcm"  // Firebase Cloud Messaging v1 (Android, iOS 모두 FCM 토큰)
+	ProviderAPNs = "apns" // Apple Push Notification service 직접 호출 (.p8 키 토큰 인증, APNs 디바이스 토큰)
+	ProviderFake = "fake" // 외부 호출 없이 보낸 메시지를 기록하는 테스트용 제공자
+)
+
+// Message 는 디바이스 하나로 보내는 알림입니다.
+type Message struct {
+	Token string
+	Title string
+	Body  string
+	// 앱으로 함께 전달하는 값 (알림 종류, 이동할 화면 등)
+	Data map[string]string
+}
+
+// PushSender 는 푸시 발송을 추상화합니다. 실패하면 *Error 를 반환합니다.
+type PushSender interface {
+	// Name 은 제공자 이름을 반환합니다. (fcm, apns, fake)
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// Error 는 제공자가 돌려준 발송 실패입니다.
+type Error struct {
+	Provider   string
+	StatusCode int
+	// 제공자의 오류 코드 (FCM: UNREGISTERED, QUOTA_EXCEEDED 등 / APNs: BadDeviceToken, Unregistered 등)
+	Reason  string
+	Message string
+	// 더 이상 푸시를 받을 수 없는 토큰. 디바이스 목록에서 지웁니다.
+	InvalidToken bool
+	// 토큰이 아니라 발송 설정(FCM 프로젝트, APNs 토픽/키 등)이 잘못된 오류. 토큰은 지우지 않습니다.
+	ConfigError bool
+	// 잠시 뒤 다시 보내면 성공할 수 있는 오류 (429, 5xx, 네트워크 오류)
+	Retryable bool
+	// 제공자가 알려준 재시도 대기 시간 (Retry-After)
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s (status %d): %s", e.Provider, e.Reason, e.StatusCode, e.Message)
+}
+
+// 네트워크 오류 등 응답을 받지 못한 경우의 Reason
+const ReasonNetwork = "NETWORK_ERROR"
+
+// 발송 설정 기본값
+const (
+	// 제공자별 초당 요청 수 (pushRatePerSecond)
+	defaultRatePerSecond = 50
+	// 요청 하나의 제한 시간 (pushTimeoutSeconds)
+	defaultRequestTimeout = 10 * time.Second
+	// 재시도 가능한 오류일 때 재시도 횟수 (pushMaxRetries)
+	defaultMaxRetries = 3
+)
+
+// Senders 는 디바이스 플랫폼별 PushSender 입니다.
+type Senders struct {
+	byPlatform map[string]PushSender
+	fallback   PushSender
+}
+
+// NewSenders 는 플랫폼별 제공자를 직접 지정해 Senders 를 만듭니다. byPlatform 에 없는 플랫폼은 fallback 으로 보냅니다.
+func NewSenders(byPlatform map[string]PushSender, fallback PushSender) *Senders {
+	return &Senders{byPlatform: byPlatform, fallback: fallback}
+}
+
+// ForPlatform 은 플랫폼(ios, android, web, unknown)에 쓸 PushSender 를 반환합니다.
+func (s *Senders) ForPlatform(platform string) PushSender {
+	if sender, ok := s.byPlatform[platform]; ok {
+		return sender
+	}
+	return s.fallback
+}
+
+// NewSendersFromConfig 는 설정값으로 플랫폼별 PushSender 를 만듭니다.
+// 기본 제공자는 pushProvider (비우면 fcm), 플랫폼별 제공자는 pushProviders.<플랫폼> 입니다.
+//
+//	pushProvider: fcm
+//	pushProviders:
+//	  ios: apns
+//
+// 모든 제공자는 pushRatePerSecond, pushMaxRetries 로 초당 요청 수와 재시도를 제한합니다. (제공자별로 따로 셉니다)
+func NewSendersFromConfig(platforms []string) (*Senders, error) {
+	created := map[string]PushSender{}
+	get := func(name string) (PushSender, error) {
+		if name == "" {
+			name = ProviderFCM
+		}
+		if sender, ok := created[name]; ok {
+			return sender, nil
+		}
+
+		sender, err := NewSenderByName(name)
+		if err != nil {
+			return nil, err
+		}
+		sender = WithRetry(sender, intConfig("pushRatePerSecond", defaultRatePerSecond), intConfig("pushMaxRetries", defaultMaxRetries))
+		created[name] = sender
+		return sender, nil
+	}
+
+	fallback, err := get(config.GetEnvConfig("pushProvider"))
+	if err != nil {
+		return nil, err
+	}
+
+	byPlatform := map[string]PushSender{}
+	for _, platform := range platforms {
+		name := config.GetEnvConfig("pushProviders." + platform)
+		if name == "" {
+			continue
+		}
+		sender, err := get(name)
+		if err != nil {
+			return nil, fmt.Errorf("pushProviders.%s: %v", platform, err)
+		}
+		byPlatform[platform] = sender
+	}
+
+	return NewSenders(byPlatform, fallback), nil
+}
+
+// NewSenderByName 은 제공자 이름으로 PushSender 를 만듭니다. 인증 정보는 설정값을 사용합니다.
+func NewSenderByName(name string) (PushSender, error) {
+	timeout := time.Duration(intConfig("pushTimeoutSeconds", int(defaultRequestTimeout/time.Second))) * time.Second
+
+	switch strings.ToLower(name) {
+	case "", ProviderFCM:
+		credentialsFile := config.GetEnvConfig("fcmCredentialsFile")
+		if credentialsFile == "" {
+			credentialsFile = "fireBaseKey.json"
+		}
+		return NewFCMSender(credentialsFile, config.GetEnvConfig("projectId"), config.GetEnvConfig("fcmEndpoint"), timeout)
+
+	case ProviderAPNs:
+		return NewAPNsSender(APNsConfig{
+			KeyFile:  config.GetEnvConfig("apnsKeyFile"),
+			KeyId:    config.GetEnvConfig("apnsKeyId"),
+			TeamId:   config.GetEnvConfig("apnsTeamId"),
+			Topic:    config.GetEnvConfig("apnsTopic"),
+			Sandbox:  config.GetEnvConfig("apnsSandbox") == "true",
+			Endpoint: config.GetEnvConfig("apnsEndpoint"),
+			Timeout:  timeout,
+		})
+
+	case ProviderFake:
+		return NewFakeSender(), nil
+
+	default:
+		return nil, fmt.Errorf("unknown push provider: %s", name)
+	}
+}
+
+func intConfig(key string, defaultValue int) int {
+	n, err := strconv.Atoi(config.GetEnvConfig(key))
+	if err != nil || n < 0 {
+		return defaultValue
+	}
+	return n
+}
@@ -1,6 +1,9 @@
 package routes
 
 import (
+	"joosum-backend/app/aiprompt"
+	"joosum-backend/app/aiusage"
+	"joosum-backend/app/link"
 	"joosum-backend/app/user"
 	"joosum-backend/pkg/middleware"
 
@@ -10,11 +13,19 @@ import (
 // InternalRoutes 내부 서비스용 API 라우터를 설정합니다.
 func InternalRoutes(router *gin.Engine) {
 	userHandler := user.UserHandler{}
+	linkBookHandler := link.LinkBookHandler{}
+	aiUsageHandler := aiusage.AIUsageHandler{}
+	aiPromptHandler := aiprompt.AIPromptHandler{}
 
 	internal := router.Group("")
 	internal.Use(middleware.InternalAPIKeyMiddleware())
 	{
 		internal.GET("/withdraw-users", userHandler.GetWithdrawUsers)
 		internal.GET("/signup-check", userHandler.CheckUserSignupByEmail)
+		internal.GET("/link-book-name-drift", linkBookHandler.GetLinkBookNameDrift)
+		internal.POST("/link-book-name-drift/repair", linkBookHandler.RepairLinkBookNameDrift)
+		internal.GET("/ai-usage", aiUsageHandler.GetAIUsage)
+		internal.GET("/ai-prompts", aiPromptHandler.GetAIPrompts)
+		internal.PUT("/ai-prompts", aiPromptHandler.SaveAIPrompt)
 	}
 }
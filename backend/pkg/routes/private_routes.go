@@ -10,6 +10,7 @@ import (
 	"joosum-backend/app/tag"
 	"joosum-backend/app/user"
 	"joosum-backend/pkg/middleware"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -55,6 +56,7 @@ func PrivateRoutes(router *gin.Engine) {
 		linkBookRouter.POST("", linkBookHandler.CreateLinkBook)
 		linkBookRouter.PUT("/:linkBookId", linkBookHandler.UpdateLinkBook)
 		linkBookRouter.DELETE("/:linkBookId", linkBookHandler.DeleteLinkBook)
+		linkBookRouter.POST("/:linkBookId/merge", linkBookHandler.MergeLinkBook)
 		linkBookRouter.GET("/:linkBookId/links", linkHandler.GetLinksByLinkBookId)
 		linkBookRouter.DELETE("/:linkBookId/links", linkHandler.DeleteLinksByLinkBookId)
 	}
@@ -63,6 +65,12 @@ func PrivateRoutes(router *gin.Engine) {
 	{
 		linkRouter.POST("", linkHandler.CreateLink)
 		linkRouter.GET("", linkHandler.GetLinks)
+		linkRouter.GET("/semantic-search", linkHandler.SemanticSearchLinks)
+		linkRouter.POST("/ask",
+			middleware.UserRateLimit("askLinks",
+				middleware.RateLimitRule{LimitKey: "askRateLimitPerMinute", DefaultLimit: 5, Window: time.Minute},
+				middleware.RateLimitRule{LimitKey: "askRateLimitPerDay", DefaultLimit: 50, Window: 24 * time.Hour}),
+			linkHandler.AskLinks)
 		linkRouter.GET("/:linkId", linkHandler.GetLinkByLinkId)
 		linkRouter.DELETE("/:linkId", linkHandler.DeleteLinkByLinkId)
 		linkRouter.DELETE("", linkHandler.DeleteLinksByUserId)
@@ -71,11 +79,21 @@ func PrivateRoutes(router *gin.Engine) {
 		linkRouter.PUT("/:linkId", linkHandler.UpdateTitleAndUrlByLinkId)
 		linkRouter.POST("/thumbnail", linkHandler.GetThumnailURL)
 		linkRouter.POST("/ai-tags", linkHandler.GetAIRecommendedTags)
+		linkRouter.POST("/:linkId/summary", linkHandler.GenerateLinkSummary)
+		linkRouter.GET("/:linkId/link-book-suggestions", linkHandler.GetLinkBookSuggestions)
+		linkRouter.POST("/:linkId/reminders", linkHandler.CreateLinkReminder)
+		linkRouter.GET("/:linkId/reminders", linkHandler.GetLinkReminders)
+		linkRouter.DELETE("/:linkId/reminders", linkHandler.CancelLinkReminders)
+		linkRouter.POST("/auto-file", linkHandler.AutoFileLinks)
+		linkRouter.GET("/auto-file", linkHandler.GetAutoFilings)
+		linkRouter.POST("/auto-file/:autoFilingId/undo", linkHandler.UndoAutoFiling)
 	}
 
 	settingRouter := router.Group("/settings")
 	{
 		settingRouter.POST("/device", settingHandler.SaveDeviceId)
+		settingRouter.GET("/devices", settingHandler.GetDevices)
+		settingRouter.DELETE("/devices/:id", settingHandler.DeleteDevice)
 		settingRouter.GET("/notification", settingHandler.GetNotificationAgree)
 		settingRouter.PUT("/notification", settingHandler.UpdatePushNotification)
 	}
@@ -83,7 +101,11 @@ func PrivateRoutes(router *gin.Engine) {
 	notificationRouter := router.Group("/notifications")
 	{
 		notificationRouter.GET("", notificationHandler.Notifications)
+		notificationRouter.GET("/unread-count", notificationHandler.UnreadCount)
+		notificationRouter.PUT("/read-all", notificationHandler.ReadAllNotifications)
 		notificationRouter.PUT("/:notificationId", notificationHandler.ReadNotification)
+		notificationRouter.DELETE("", notificationHandler.DeleteNotifications)
+		notificationRouter.DELETE("/:notificationId", notificationHandler.DeleteNotification)
 	}
 
 	bannerRouter := router.Group("/banners")
@@ -0,0 +1,64 @@
+package tagpolicy
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// 태그 최대 개수
+const MaxTags = 5
+
+// Rejected 는 필터에서 제외된 태그와 이유입니다.
+type Rejected struct {
+	Tag     string
+	Reasons []string
+}
+
+// 같은 태그가 이미 있어 제외한 경우의 이유
+const ReasonDuplicate = "duplicate"
+
+// Normalize 는 태그 표기를 정리합니다.
+// 유니코드 NFC 정규화, 앞뒤 공백/따옴표/# 제거, 연속 공백을 하나로 합칩니다.
+func Normalize(tag string) string {
+	tag = norm.NFC.String(tag)
+	tag = strings.TrimFunc(tag, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune("\"'`#,", r)
+	})
+	return strings.Join(strings.Fields(tag), " ")
+}
+
+// Filter 는 LLM 이 추천한 태그를 정규화한 뒤 ai_tag_policy.md 를 어기는 태그와 중복을 제외합니다.
+// 대소문자, 띄어쓰기만 다른 태그(UX리서치/ux 리서치)는 먼저 나온 것만 남기며, 최대 MaxTags 개를 반환합니다.
+func Filter(tags []string, linkURL string) ([]string, []Rejected) {
+	kept := []string{}
+	var rejected []Rejected
+	seen := map[string]bool{}
+
+	for _, raw := range tags {
+		tag := Normalize(raw)
+		if tag == "" {
+			continue
+		}
+
+		if violations := Check(tag, linkURL); len(violations) > 0 {
+			rejected = append(rejected, Rejected{Tag: raw, Reasons: violations})
+			continue
+		}
+
+		key := compact(tag)
+		if seen[key] {
+			rejected = append(rejected, Rejected{Tag: raw, Reasons: []string{ReasonDuplicate}})
+			continue
+		}
+		seen[key] = true
+
+		if len(kept) == MaxTags {
+			continue
+		}
+		kept = append(kept, tag)
+	}
+
+	return kept, rejected
+}
@@ -0,0 +1,177 @@
+package tagpolicy
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ai_tag_policy.md 의 제외 규칙 위반 유형
+const (
+	ViolationDomain    = "domain"    // 도메인명/플랫폼명
+	ViolationDate      = "date"      // 날짜, 버전 정보
+	ViolationEmoji     = "emoji"     // 이모지/특수문자 포함
+	ViolationSentence  = "sentence"  // 문장형 표현/명령형
+	ViolationTooLong   = "too_long"  // 10자 초과
+	ViolationCTA       = "cta"       // 행동 유도형 문구
+	ViolationAdjective = "adjective" // 감정·의미 없는 형용사/부사
+)
+
+// 태그 최대 글자 수
+const MaxTagLength = 10
+
+// 출처로만 쓰이는 블로그/매거진 플랫폼. 링크의 도메인이 아니어도 태그로 쓰지 않습니다.
+var platformNames = map[string]bool{
+	"yozm": true, "wishket": true, "naver": true, "brunch": true, "medium": true,
+	"tistory": true, "velog": true, "daum": true, "blog": true, "substack": true,
+	"wordpress": true, "egloos": true, "postype": true,
+}
+
+// 도메인에서 태그 비교에 쓰지 않는 부분 (www, 최상위 도메인)
+var hostStopLabels = map[string]bool{
+	"www": true, "m": true, "com": true, "net": true, "org": true, "co": true,
+	"kr": true, "io": true, "me": true, "dev": true, "app": true, "ai": true,
+}
+
+var ctaWords = map[string]bool{
+	"클릭": true, "확인": true, "공유": true, "신청": true, "신청하기": true, "구독": true,
+	"구독하기": true, "좋아요": true, "댓글": true, "더보기": true, "바로가기": true,
+	"다운로드": true, "알림설정": true, "지금확인": true, "공유하기": true,
+	"click": true, "share": true, "subscribe": true, "like": true, "download": true,
+	"signup": true, "readmore": true,
+}
+
+var adjectiveWords = map[string]bool{
+	"멋진": true, "새로운": true, "유용한": true, "좋은": true, "빠르게": true,
+	"최고의": true, "놀라운": true, "완벽한": true, "쉬운": true, "재미있는": true,
+	"awesome": true, "amazing": true, "best": true, "new": true, "useful": true,
+	"great": true, "cool": true,
+}
+
+// 문장형 표현의 끝말
+var sentenceSuffixes = []string{
+	"하는방법", "하는법", "방법", "이유", "해보자", "하자", "하세요", "합니다", "입니다",
+	"할까", "봐야할", "해야할",
+}
+
+var (
+	// 2024, 2024년, 24년
+	yearPattern = regexp.MustCompile(`^((19|20)\d{2}|\d{2})년?$`)
+	// 7월, 7월15일, 15일
+	monthDayPattern = regexp.MustCompile(`^(\d{1,2}월)?(\d{1,2}일)?$`)
+	// 1.0, v2.3.1
+	versionPattern = regexp.MustCompile(`^[vV]?\d+(\.\d+)+$`)
+	numberPattern  = regexp.MustCompile(`^\d+$`)
+)
+
+// Check 는 태그가 ai_tag_policy.md 의 제외 규칙을 어기는지 검사하고 위반 유형을 반환합니다.
+// 플랫폼명과, linkURL 의 도메인을 도메인 형태(github.com, notion.so)로 쓴 태그는 도메인명으로 봅니다.
+// Notion, Figma 처럼 링크 도메인과 같은 단어만으로는 제외하지 않습니다. (그 서비스를 다루는 글에서는 주제어입니다)
+func Check(tag string, linkURL string) []string {
+	var violations []string
+
+	trimmed := strings.TrimSpace(tag)
+	key := compact(trimmed)
+
+	if platformNames[key] || isLinkDomain(key, linkURL) {
+		violations = append(violations, ViolationDomain)
+	}
+	if isDate(key) {
+		violations = append(violations, ViolationDate)
+	}
+	if hasEmojiOrSymbol(trimmed) {
+		violations = append(violations, ViolationEmoji)
+	}
+	if isSentence(trimmed, key) {
+		violations = append(violations, ViolationSentence)
+	}
+	if utf8.RuneCountInString(trimmed) > MaxTagLength {
+		violations = append(violations, ViolationTooLong)
+	}
+	if ctaWords[key] {
+		violations = append(violations, ViolationCTA)
+	}
+	if adjectiveWords[key] {
+		violations = append(violations, ViolationAdjective)
+	}
+
+	return violations
+}
+
+// compact 는 비교용으로 소문자로 바꾸고 공백을 없앱니다.
+func compact(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), "")
+}
+
+// isLinkDomain 은 태그가 linkURL 의 호스트 이름이나 "<도메인 단어>.com" 인지 확인합니다.
+func isLinkDomain(key string, linkURL string) bool {
+	if linkURL == "" || key == "" {
+		return false
+	}
+
+	parsed, err := url.Parse(linkURL)
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if host == "" {
+		return false
+	}
+	if key == host || key == strings.TrimPrefix(host, "www.") {
+		return true
+	}
+
+	for _, label := range strings.Split(host, ".") {
+		// go.dev 의 Go 처럼 짧은 도메인은 주제어와 겹치는 경우가 많아 비교하지 않음
+		if hostStopLabels[label] || utf8.RuneCountInString(label) < 3 {
+			continue
+		}
+		if key == label+".com" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isDate(key string) bool {
+	if key == "" {
+		return false
+	}
+	return yearPattern.MatchString(key) || monthDayPattern.MatchString(key) ||
+		versionPattern.MatchString(key) || numberPattern.MatchString(key)
+}
+
+// hasEmojiOrSymbol 은 이모지나 태그에 쓰지 않는 특수문자가 있는지 확인합니다.
+// C++, C#, Node.js, UX/UI 처럼 기술 용어에 쓰이는 + # . / - _ & 는 허용합니다.
+func hasEmojiOrSymbol(tag string) bool {
+	for _, r := range tag {
+		switch {
+		case unicode.IsLetter(r), unicode.IsNumber(r), unicode.IsSpace(r):
+			continue
+		case strings.ContainsRune("+#./-_&", r):
+			continue
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+func isSentence(tag string, key string) bool {
+	if len(strings.Fields(tag)) >= 3 {
+		return true
+	}
+	if strings.HasSuffix(tag, "?") || strings.HasSuffix(tag, "!") || strings.HasSuffix(tag, ".") {
+		return true
+	}
+	for _, suffix := range sentenceSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package tagpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		url  string
+		want []string
+	}{
+		// 통과
+		{name: "일반 주제어", tag: "디자인시스템", want: nil},
+		{name: "영문 주제어", tag: "React", want: nil},
+		{name: "기술 용어의 특수문자", tag: "C++", want: nil},
+		{name: "점이 들어간 기술 용어", tag: "Node.js", want: nil},
+		{name: "슬래시", tag: "UX/UI", want: nil},
+		{name: "10자", tag: "가나다라마바사아자차", want: nil},
+
+		// 문장형 표현
+		{name: "방법으로 끝남", tag: "글쓰기방법", want: []string{ViolationSentence}},
+		{name: "띄어 쓴 하는 방법", tag: "공부 하는 법", want: []string{ViolationSentence}},
+		{name: "입니다로 끝남", tag: "핵심입니다", want: []string{ViolationSentence}},
+		{name: "물음표", tag: "왜 React?", want: []string{ViolationEmoji, ViolationSentence}},
+		{name: "세 단어 이상", tag: "AI 에이전트 설계", want: []string{ViolationSentence}},
+
+		// 날짜, 버전
+		{name: "연도", tag: "2024", want: []string{ViolationDate}},
+		{name: "연도 년", tag: "2024년", want: []string{ViolationDate}},
+		{name: "두 자리 연도", tag: "24년", want: []string{ViolationDate}},
+		{name: "월일", tag: "7월15일", want: []string{ViolationDate}},
+		{name: "월", tag: "12월", want: []string{ViolationDate}},
+		{name: "버전", tag: "v2.3.1", want: []string{ViolationDate}},
+		{name: "숫자", tag: "100", want: []string{ViolationDate}},
+
+		// 도메인명, 플랫폼명
+		{name: "링크 도메인 .com", tag: "github.com", url: "https://github.com/org/repo", want: []string{ViolationDomain}},
+		{name: "링크 도메인 대소문자", tag: "GitHub.com", url: "https://github.com/org/repo", want: []string{ViolationDomain}},
+		{name: "링크 호스트 이름", tag: "notion.so", url: "https://www.notion.so/product", want: []string{ViolationDomain}},
+		{name: "www 를 뺀 호스트 이름", tag: "joosum.com", url: "https://www.joosum.com/post/1", want: []string{ViolationDomain}},
+		{name: "서브도메인 포함 호스트 이름", tag: "docs.python.org", url: "https://docs.python.org/3/", want: []string{ViolationDomain, ViolationTooLong}},
+		{name: "자기 사이트의 서비스명 Notion", tag: "Notion", url: "https://www.notion.so/product", want: nil},
+		{name: "자기 사이트의 서비스명 Figma", tag: "Figma", url: "https://www.figma.com/blog/config-2024", want: nil},
+		{name: "자기 사이트의 서비스명 OpenAI", tag: "OpenAI", url: "https://openai.com/index/gpt-4o", want: nil},
+		{name: "자기 사이트의 서비스명 Apple", tag: "Apple", url: "https://www.apple.com/newsroom/", want: nil},
+		{name: "자기 사이트의 서비스명 GitHub", tag: "GitHub", url: "https://github.com/org/repo", want: nil},
+		{name: "링크 도메인과 같은 단어", tag: "docs", url: "https://docs.python.org/3/", want: nil},
+		{name: "짧은 도메인은 비교하지 않음", tag: "Go", url: "https://go.dev/doc", want: nil},
+		{name: "최상위 도메인은 비교하지 않음", tag: "dev", url: "https://go.dev/doc", want: nil},
+		{name: "다른 링크에서는 통과", tag: "joosum.com", url: "https://example.com", want: nil},
+		{name: "플랫폼명은 자기 사이트에서도 제외", tag: "brunch", url: "https://brunch.co.kr/@wine-ny/163", want: []string{ViolationDomain}},
+		{name: "플랫폼명", tag: "Velog", want: []string{ViolationDomain}},
+		{name: "플랫폼명 blog", tag: "blog", url: "https://example.com", want: []string{ViolationDomain}},
+
+		// 이모지, 특수문자
+		{name: "이모지", tag: "🔥트렌드", want: []string{ViolationEmoji}},
+		{name: "특수문자", tag: "AI★", want: []string{ViolationEmoji}},
+		{name: "괄호", tag: "LLM(대형)", want: []string{ViolationEmoji}},
+
+		// 길이
+		{name: "11자", tag: "가나다라마바사아자차카", want: []string{ViolationTooLong}},
+		{name: "영문 11자", tag: "Kubernetess", want: []string{ViolationTooLong}},
+
+		// 행동 유도, 형용사
+		{name: "행동 유도", tag: "구독하기", want: []string{ViolationCTA}},
+		{name: "영문 행동 유도", tag: "Subscribe", want: []string{ViolationCTA}},
+		{name: "형용사", tag: "유용한", want: []string{ViolationAdjective}},
+
+		// 여러 규칙 위반
+		{name: "날짜와 이모지", tag: "2024🎉", want: []string{ViolationEmoji}},
+		{name: "문장형이고 긺", tag: "프론트엔드 개발자가 되는 방법", want: []string{ViolationSentence, ViolationTooLong}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Check(tt.tag, tt.url); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check(%q, %q) = %v, want %v", tt.tag, tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want string
+	}{
+		{name: "앞뒤 공백", tag: "  디자인  ", want: "디자인"},
+		{name: "해시태그", tag: "#디자인", want: "디자인"},
+		{name: "따옴표", tag: `"React"`, want: "React"},
+		{name: "연속 공백", tag: "UX   리서치", want: "UX 리서치"},
+		{name: "탭과 줄바꿈", tag: "UX\t\n리서치", want: "UX 리서치"},
+		{name: "C# 의 # 는 끝에 있어도 제거", tag: "C#", want: "C"},
+		{name: "NFD 한글을 NFC 로", tag: norm.NFD.String("리서치"), want: "리서치"},
+		{name: "빈 태그", tag: " # ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.tag); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name         string
+		tags         []string
+		url          string
+		want         []string
+		wantRejected []Rejected
+	}{
+		{
+			name: "정규화한 태그 반환",
+			tags: []string{" #디자인 ", "UX  리서치"},
+			want: []string{"디자인", "UX 리서치"},
+		},
+		{
+			name:         "대소문자와 띄어쓰기만 다른 태그는 먼저 나온 것만",
+			tags:         []string{"UX리서치", "ux 리서치", "React", "REACT"},
+			want:         []string{"UX리서치", "React"},
+			wantRejected: []Rejected{{Tag: "ux 리서치", Reasons: []string{ReasonDuplicate}}, {Tag: "REACT", Reasons: []string{ReasonDuplicate}}},
+		},
+		{
+			name:         "NFC 와 NFD 로 쓴 같은 태그",
+			tags:         []string{"리서치", norm.NFD.String("리서치")},
+			want:         []string{"리서치"},
+			wantRejected: []Rejected{{Tag: norm.NFD.String("리서치"), Reasons: []string{ReasonDuplicate}}},
+		},
+		{
+			name:         "규칙을 어긴 태그는 이유와 함께 제외",
+			tags:         []string{"github.com", "2024년", "디자인", "🔥트렌드"},
+			url:          "https://github.com/org/repo",
+			want:         []string{"디자인"},
+			wantRejected: []Rejected{{Tag: "github.com", Reasons: []string{ViolationDomain}}, {Tag: "2024년", Reasons: []string{ViolationDate}}, {Tag: "🔥트렌드", Reasons: []string{ViolationEmoji}}},
+		},
+		{
+			name: "빈 태그는 이유 없이 건너뜀",
+			tags: []string{"", " # ", "디자인"},
+			want: []string{"디자인"},
+		},
+		{
+			name: "최대 MaxTags 개",
+			tags: []string{"가", "나", "다", "라", "마", "바", "사"},
+			want: []string{"가", "나", "다", "라", "마"},
+		},
+		{
+			name: "추천 태그가 없으면 빈 목록",
+			tags: nil,
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rejected := Filter(tt.tags, tt.url)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Filter(%q) = %q, want %q", tt.tags, got, tt.want)
+			}
+			if !reflect.DeepEqual(rejected, tt.wantRejected) {
+				t.Errorf("Filter(%q) rejected = %+v, want %+v", tt.tags, rejected, tt.wantRejected)
+			}
+		})
+	}
+}
@@ -3,3 +3,19 @@ package util
 import "gopkg.in/errgo.v2/errors"
 
 var ErrDuplicatedTitle = errors.New("같은 이름의 폴더가 존재합니다")
+
+var ErrLinkBookNotFound = errors.New("링크북을 찾을 수 없습니다")
+
+var ErrSameLinkBook = errors.New("같은 링크북으로 이동할 수 없습니다")
+
+var ErrLinkNotFound = errors.New("링크를 찾을 수 없습니다")
+
+var ErrAutoFilingNotFound = errors.New("자동 분류 기록을 찾을 수 없습니다")
+
+var ErrAutoFilingNotUndoable = errors.New("되돌릴 수 없는 자동 분류입니다")
+
+var ErrInvalidReminderTime = errors.New("리마인더 시각이 올바르지 않습니다")
+
+var ErrAIQuotaExceeded = errors.New("AI 사용량 한도를 초과했습니다")
+
+var ErrInvalidAIPrompt = errors.New("프롬프트 템플릿이 올바르지 않습니다")
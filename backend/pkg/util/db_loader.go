@@ -23,11 +23,23 @@ func StartMongoDB() {
 	// Collection load
 	db.InitUserCollection(client, dbName)
 	db.InitLinkCollection(client, dbName)
+	db.InitLinkContentCollection(client, dbName)
+	db.InitLinkAutoFilingCollection(client, dbName)
+	db.InitLinkReminderCollection(client, dbName)
+	db.InitLinkEmbeddingCollection(client, dbName)
+	db.InitAIUsageCollection(client, dbName)
+	db.InitAITagCacheCollection(client, dbName)
+	db.InitAIPromptCollection(client, dbName)
 	db.InitLinkBookCollection(client, dbName)
 	db.InitInactiveUserCollection(client, dbName)
 	db.InitTagCollection(client, dbName)
 	db.InitNotificationCollection(client, dbName)
 	db.InitNotificationAgreeCollection(client, dbName)
+	db.InitDeviceCollection(client, dbName)
+	db.InitNotificationDeliveryCollection(client, dbName)
+	db.InitJobRunCollection(client, dbName)
+	db.InitJobLockCollection(client, dbName)
+	db.InitRateLimitCollection(client, dbName)
 
 }
 
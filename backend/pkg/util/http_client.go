@@ -0,0 +1,77 @@
+package util
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrNonPublicAddress 는 루프백, 사설망 등 인터넷 주소가 아닌 곳으로 연결하려 할 때 반환합니다.
+var ErrNonPublicAddress = errors.New("connection to non-public address not allowed")
+
+// IsPublicIP 가 추가로 막는 대역 (net.IP 의 메서드로 확인할 수 없는 것)
+var nonPublicNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),     // 현재 네트워크
+	mustParseCIDR("100.64.0.0/10"), // 통신사 NAT
+	mustParseCIDR("192.0.0.0/24"),  // IETF 프로토콜 할당
+	mustParseCIDR("198.18.0.0/15"), // 벤치마크
+	mustParseCIDR("64:ff9b::/96"),  // NAT64 (IPv4 주소를 감쌀 수 있음)
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// IsPublicIP 는 ip 가 인터넷에서 접근할 수 있는 주소인지 확인합니다.
+// 루프백, 사설망, 링크 로컬(클라우드 메타데이터 169.254.169.254 포함), 멀티캐스트, 미지정 주소는 공인 주소가 아닙니다.
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewPublicHTTPClient 는 공인 IP 로만 연결하는 HTTP 클라이언트를 만듭니다.
+// 사용자가 저장한 URL 을 서버에서 가져올 때 사용해 서버 내부망이나 메타데이터 주소로 요청하지 못하게 합니다.
+// 호스트 이름이 아니라 실제로 연결하는 IP 를 확인하므로 리다이렉트나 DNS 응답으로 우회할 수 없습니다.
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || !IsPublicIP(ip) {
+				return ErrNonPublicAddress
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// 프록시를 거치면 연결하는 IP 가 프록시 주소가 되어 확인할 수 없으므로 사용하지 않음
+	transport.Proxy = nil
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
@@ -0,0 +1,66 @@
+package util
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "8.8.8.8", want: true},
+		{ip: "142.250.196.110", want: true},
+		{ip: "2606:4700:4700::1111", want: true},
+		{ip: "127.0.0.1", want: false},
+		{ip: "::1", want: false},
+		{ip: "10.0.0.1", want: false},
+		{ip: "172.16.5.4", want: false},
+		{ip: "192.168.0.10", want: false},
+		{ip: "169.254.169.254", want: false},
+		{ip: "fd00::1", want: false},
+		{ip: "fe80::1", want: false},
+		{ip: "0.0.0.0", want: false},
+		{ip: "0.1.2.3", want: false},
+		{ip: "100.64.0.1", want: false},
+		{ip: "224.0.0.1", want: false},
+		{ip: "::ffff:127.0.0.1", want: false},
+		{ip: "::ffff:10.0.0.1", want: false},
+		{ip: "64:ff9b::a9fe:a9fe", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := IsPublicIP(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("IsPublicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicHTTPClientBlocksLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("루프백 서버로 요청함: %s", r.URL)
+	}))
+	defer server.Close()
+
+	client := NewPublicHTTPClient(5 * time.Second)
+
+	// IP 로 직접 요청
+	_, err := client.Get(server.URL)
+	if !errors.Is(err, ErrNonPublicAddress) {
+		t.Errorf("err = %v, want ErrNonPublicAddress", err)
+	}
+
+	// localhost 처럼 루프백으로 풀리는 호스트 이름
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	_, err = client.Get("http://localhost:" + port)
+	if !errors.Is(err, ErrNonPublicAddress) {
+		t.Errorf("err = %v, want ErrNonPublicAddress", err)
+	}
+}
@@ -25,23 +25,52 @@ const (
 	CodeMissingAuthorization = 1001
 	CodeInternalServerError  = 1002
 	CodeMissingParameter     = 1003
+	CodeTooManyRequests      = 1004
+	CodeInvalidParameter     = 1005
 
 	CodeInvalidIDToken   = 2000
 	CodeUserExists       = 2001
 	CodeUserRecentlyLeft = 2002
 	CodeDuplicateTitle   = 3000
+	CodeLinkBookNotFound = 3001
+	CodeSameLinkBook     = 3002
+
+	CodeLinkNotFound          = 4000
+	CodeAutoFilingNotFound    = 4001
+	CodeAutoFilingNotUndoable = 4002
+	CodeInvalidReminderTime   = 4003
+
+	CodeAIQuotaExceeded = 5000
+	CodeInvalidAIPrompt = 5001
+
+	CodeDeviceNotFound             = 6000
+	CodeInvalidNotificationSetting = 6001
+	CodeNotificationNotFound       = 6002
 )
 
 // 사전 정의된 오류 메시지(한글)
 var codeMessages = map[int]string{
-	CodeInvalidRequestBody:   "잘못된 요청 본문입니다.",
-	CodeMissingAuthorization: "Authorization 헤더가 없습니다.",
-	CodeInternalServerError:  "서버 오류가 발생했습니다.",
-	CodeMissingParameter:     "필수 파라미터가 누락되었습니다.",
-	CodeInvalidIDToken:       "유효하지 않은 ID 토큰입니다.",
-	CodeUserExists:           "이미 존재하는 사용자입니다.",
-	CodeUserRecentlyLeft:     "탈퇴 후 30일이 지나지 않았습니다.",
-	CodeDuplicateTitle:       "같은 이름의 폴더가 존재합니다.",
+	CodeInvalidRequestBody:         "잘못된 요청 본문입니다.",
+	CodeMissingAuthorization:       "Authorization 헤더가 없습니다.",
+	CodeInternalServerError:        "서버 오류가 발생했습니다.",
+	CodeMissingParameter:           "필수 파라미터가 누락되었습니다.",
+	CodeTooManyRequests:            "요청이 너무 많습니다. 잠시 후 다시 시도해주세요.",
+	CodeInvalidParameter:           "파라미터 값이 올바르지 않습니다.",
+	CodeInvalidIDToken:             "유효하지 않은 ID 토큰입니다.",
+	CodeUserExists:                 "이미 존재하는 사용자입니다.",
+	CodeUserRecentlyLeft:           "탈퇴 후 30일이 지나지 않았습니다.",
+	CodeDuplicateTitle:             "같은 이름의 폴더가 존재합니다.",
+	CodeLinkBookNotFound:           "폴더를 찾을 수 없습니다.",
+	CodeSameLinkBook:               "같은 폴더로 이동할 수 없습니다.",
+	CodeLinkNotFound:               "링크를 찾을 수 없습니다.",
+	CodeAutoFilingNotFound:         "자동 분류 기록을 찾을 수 없습니다.",
+	CodeAutoFilingNotUndoable:      "이미 다른 폴더로 옮겨졌거나 되돌린 링크입니다.",
+	CodeInvalidReminderTime:        "리마인더 시각이 올바르지 않습니다.",
+	CodeAIQuotaExceeded:            "AI 기능 사용량 한도를 초과했습니다.",
+	CodeInvalidAIPrompt:            "프롬프트 템플릿이 올바르지 않습니다.",
+	CodeDeviceNotFound:             "디바이스를 찾을 수 없습니다.",
+	CodeInvalidNotificationSetting: "알림 설정 값이 올바르지 않습니다. (시간대, 언어, 조용한 시간, 알림 시각, 알림 종류, 빈도)",
+	CodeNotificationNotFound:       "알림을 찾을 수 없습니다.",
 }
 
 // SendError 는 오류 응답을 JSON 형태로 클라이언트에 반환합니다.
@@ -0,0 +1,141 @@
+package util
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"joosum-backend/pkg/db"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RateLimit 은 window 동안 허용하는 요청 수입니다.
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+// RateLimiter 는 키(사용자 아이디 등)별로 요청 수를 제한하는 슬라이딩 윈도우 제한기입니다.
+// 여러 window 의 제한(분당, 하루 등)을 한 기록으로 함께 검사하고, 모든 제한을 통과한 요청만 기록합니다.
+// 요청 기록을 rateLimits 컬렉션에 두므로 여러 인스턴스에서도 함께 제한됩니다.
+type RateLimiter struct {
+	name   string
+	limits []RateLimit
+	// 기록을 남겨 두는 기간 (가장 긴 window)
+	window time.Duration
+}
+
+// NewRateLimiter 는 name 으로 구분하는 제한기를 만듭니다. 같은 키라도 name 이 다르면 따로 셉니다.
+func NewRateLimiter(name string, limits ...RateLimit) *RateLimiter {
+	var window time.Duration
+	for _, limit := range limits {
+		if limit.Window > window {
+			window = limit.Window
+		}
+	}
+
+	return &RateLimiter{
+		name:   name,
+		limits: limits,
+		window: window,
+	}
+}
+
+type rateLimitRecord struct {
+	Hits    []time.Time `bson:"hits"`
+	Allowed bool        `bson:"allowed"`
+}
+
+// Allow 는 모든 제한을 통과하면 요청을 기록하고 true 를 반환합니다.
+// 허용하지 않으면 다시 요청할 수 있을 때까지 남은 시간을 함께 반환합니다.
+// 기록을 읽거나 쓰지 못하면 요청을 막지 않습니다.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"_id": l.name + ":" + key}
+
+	// 제한마다 window 안의 기록 수가 limit 보다 적어야 함
+	withinLimits := bson.A{}
+	for _, limit := range l.limits {
+		withinLimits = append(withinLimits, bson.M{"$lt": bson.A{
+			bson.M{"$size": bson.M{"$filter": bson.M{
+				"input": "$hits",
+				"cond":  bson.M{"$gt": bson.A{"$$this", now.Add(-limit.Window)}},
+			}}},
+			limit.Limit,
+		}})
+	}
+
+	// 가장 긴 window 가 지난 기록을 지우고, 모든 제한을 통과할 때만 이번 요청을 추가 (한 문서를 원자적으로 수정)
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"hits": bson.M{"$filter": bson.M{
+				"input": bson.M{"$ifNull": bson.A{"$hits", bson.A{}}},
+				"cond":  bson.M{"$gt": bson.A{"$$this", now.Add(-l.window)}},
+			}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"allowed": bson.M{"$and": withinLimits},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"hits": bson.M{"$cond": bson.A{"$allowed", bson.M{"$concatArrays": bson.A{"$hits", bson.A{now}}}, "$hits"}},
+			// TTL 인덱스로 마지막 요청 후 window 가 지나면 삭제
+			"expires_at": now.Add(l.window),
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var record rateLimitRecord
+	err := db.RateLimitCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&record)
+	if mongo.IsDuplicateKeyError(err) {
+		// 같은 키의 첫 요청이 동시에 들어와 upsert 가 겹친 경우 한 번 더 시도
+		err = db.RateLimitCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&record)
+	}
+	if err != nil {
+		log.Printf("[요청 제한] 기록 실패로 요청 허용 (name=%s, key=%s): %v", l.name, key, err)
+		return true, 0
+	}
+
+	if record.Allowed {
+		return true, 0
+	}
+
+	return false, l.retryAfter(record.Hits, now)
+}
+
+// retryAfter 는 hits(오래된 순)가 있을 때 모든 제한을 통과할 수 있을 때까지 남은 시간입니다.
+func (l *RateLimiter) retryAfter(hits []time.Time, now time.Time) time.Duration {
+	var wait time.Duration
+	for _, limit := range l.limits {
+		var inWindow []time.Time
+		for _, hit := range hits {
+			if hit.After(now.Add(-limit.Window)) {
+				inWindow = append(inWindow, hit)
+			}
+		}
+		if len(inWindow) < limit.Limit {
+			continue
+		}
+
+		// window 안의 기록이 limit 보다 적어질 때까지 기다림
+		if limit.Limit <= 0 {
+			wait = maxDuration(wait, limit.Window)
+			continue
+		}
+		wait = maxDuration(wait, inWindow[len(inWindow)-limit.Limit].Add(limit.Window).Sub(now))
+	}
+
+	return wait
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
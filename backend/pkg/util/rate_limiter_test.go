@@ -0,0 +1,63 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+
+	limiter := NewRateLimiter("test",
+		RateLimit{Limit: 2, Window: time.Minute},
+		RateLimit{Limit: 4, Window: 24 * time.Hour},
+	)
+
+	tests := []struct {
+		name string
+		hits []time.Time
+		want time.Duration
+	}{
+		{
+			name: "분당 제한만 넘음",
+			hits: []time.Time{ago(50 * time.Second), ago(20 * time.Second)},
+			want: 10 * time.Second,
+		},
+		{
+			name: "하루 제한만 넘음",
+			hits: []time.Time{ago(4 * time.Hour), ago(3 * time.Hour), ago(2 * time.Hour), ago(time.Hour)},
+			want: 20 * time.Hour,
+		},
+		{
+			name: "둘 다 넘으면 더 긴 쪽",
+			hits: []time.Time{ago(23 * time.Hour), ago(22 * time.Hour), ago(30 * time.Second), ago(10 * time.Second)},
+			want: time.Hour,
+		},
+		{
+			name: "limit 보다 많이 기록된 경우 limit 보다 적어질 때까지",
+			hits: []time.Time{ago(50 * time.Second), ago(40 * time.Second), ago(30 * time.Second)},
+			want: 20 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limiter.retryAfter(tt.hits, now); got != tt.want {
+				t.Errorf("retryAfter = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRateLimiterWindow(t *testing.T) {
+	limiter := NewRateLimiter("test",
+		RateLimit{Limit: 5, Window: time.Minute},
+		RateLimit{Limit: 50, Window: 24 * time.Hour},
+	)
+
+	// 기록은 가장 긴 window 동안 남겨 둠
+	if limiter.window != 24*time.Hour {
+		t.Errorf("window = %v, want 24h", limiter.window)
+	}
+}
@@ -1,6 +1,9 @@
 package util
 
-import "strings"
+import (
+	"net/url"
+	"strings"
+)
 
 // EnsureHTTPPrefix 는 URL 앞에 http:// 혹은 https:// 가 없으면 https:// 를 붙여 반환합니다.
 func EnsureHTTPPrefix(url string) string {
@@ -12,3 +15,51 @@ func EnsureHTTPPrefix(url string) string {
 	}
 	return "https://" + url
 }
+
+// 캐시 키를 만들 때 무시하는 추적용 쿼리 파라미터
+var trackingQueryParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"igshid":  true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"ref_src": true,
+}
+
+// CanonicalURL 은 같은 페이지를 가리키는 URL 이 같은 문자열이 되도록 정규화합니다.
+// 스킴/호스트 소문자화, www. 와 기본 포트 제거, 프래그먼트와 추적용 쿼리(utm_* 등) 제거, 쿼리 정렬, 끝 슬래시 제거를 합니다.
+// 파싱할 수 없는 URL 은 그대로 반환합니다.
+func CanonicalURL(rawURL string) string {
+	trimmed := strings.TrimSpace(rawURL)
+	if !strings.Contains(trimmed, "://") {
+		trimmed = EnsureHTTPPrefix(trimmed)
+	}
+
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if port := parsed.Port(); port != "" && !(scheme == "http" && port == "80") && !(scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+
+	query := parsed.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") || trackingQueryParams[strings.ToLower(key)] {
+			query.Del(key)
+		}
+	}
+
+	canonical := url.URL{
+		Scheme:   scheme,
+		Host:     host,
+		Path:     strings.TrimSuffix(parsed.Path, "/"),
+		RawPath:  strings.TrimSuffix(parsed.RawPath, "/"),
+		RawQuery: query.Encode(), // Encode 는 키 순서로 정렬
+	}
+
+	return canonical.String()
+}
@@ -0,0 +1,79 @@
+package vector
+
+import (
+	"math"
+	"sort"
+)
+
+type Match struct {
+	ID    string
+	Score float64
+}
+
+// MemoryIndex 는 벡터를 메모리에 두고 전부 비교하는 순수 Go 인덱스입니다.
+// 사용자 한 명의 링크처럼 작은 집합을 검색하거나 Atlas Vector Search 가 없는 로컬 개발/테스트 환경에서 사용합니다.
+type MemoryIndex struct {
+	ids       []string
+	vectors   [][]float32
+	positions map[string]int
+}
+
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{positions: map[string]int{}}
+}
+
+// Add 는 벡터를 추가합니다. 같은 id 를 다시 추가하면 덮어씁니다.
+func (idx *MemoryIndex) Add(id string, vector []float32) {
+	if i, ok := idx.positions[id]; ok {
+		idx.vectors[i] = vector
+		return
+	}
+
+	idx.positions[id] = len(idx.ids)
+	idx.ids = append(idx.ids, id)
+	idx.vectors = append(idx.vectors, vector)
+}
+
+func (idx *MemoryIndex) Len() int {
+	return len(idx.ids)
+}
+
+// Search 는 query 와 코사인 유사도가 높은 순서로 최대 k 개를 반환합니다.
+// 차원이 다른 벡터는 비교하지 않습니다.
+func (idx *MemoryIndex) Search(query []float32, k int) []Match {
+	matches := make([]Match, 0, len(idx.ids))
+	for i, vector := range idx.vectors {
+		if len(vector) != len(query) {
+			continue
+		}
+		matches = append(matches, Match{ID: idx.ids[i], Score: CosineSimilarity(query, vector)})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if k > 0 && len(matches) > k {
+		matches = matches[:k]
+	}
+
+	return matches
+}
+
+// CosineSimilarity 는 두 벡터의 코사인 유사도(-1~1)를 반환합니다. 길이가 다르거나 영벡터면 0 입니다.
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
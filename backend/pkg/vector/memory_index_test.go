@@ -0,0 +1,97 @@
+package vector
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []float32
+		b    []float32
+		want float64
+	}{
+		{name: "같은 방향", a: []float32{1, 2, 3}, b: []float32{2, 4, 6}, want: 1},
+		{name: "반대 방향", a: []float32{1, 0}, b: []float32{-1, 0}, want: -1},
+		{name: "직교", a: []float32{1, 0}, b: []float32{0, 1}, want: 0},
+		{name: "45도", a: []float32{1, 0}, b: []float32{1, 1}, want: 1 / math.Sqrt2},
+		{name: "길이가 다름", a: []float32{1, 0}, b: []float32{1, 0, 0}, want: 0},
+		{name: "영벡터", a: []float32{0, 0}, b: []float32{1, 1}, want: 0},
+		{name: "빈 벡터", a: []float32{}, b: []float32{}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CosineSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("CosineSimilarity(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryIndexSearch(t *testing.T) {
+	vectors := []struct {
+		id     string
+		vector []float32
+	}{
+		{id: "x", vector: []float32{1, 0}},
+		{id: "xy", vector: []float32{1, 1}},
+		{id: "y", vector: []float32{0, 1}},
+		{id: "-x", vector: []float32{-1, 0}},
+		{id: "3d", vector: []float32{1, 0, 0}},
+	}
+
+	tests := []struct {
+		name  string
+		query []float32
+		k     int
+		want  []string
+	}{
+		{name: "유사도 순", query: []float32{1, 0}, k: 0, want: []string{"x", "xy", "y", "-x"}},
+		{name: "최대 k 개", query: []float32{1, 0}, k: 2, want: []string{"x", "xy"}},
+		{name: "k 가 개수보다 큼", query: []float32{0, 1}, k: 10, want: []string{"y", "xy", "x", "-x"}},
+		{name: "같은 차원만 비교", query: []float32{0, 0, 1}, k: 0, want: []string{"3d"}},
+		{name: "같은 차원이 없음", query: []float32{1}, k: 0, want: []string{}},
+	}
+
+	idx := NewMemoryIndex()
+	for _, v := range vectors {
+		idx.Add(v.id, v.vector)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := []string{}
+			for _, m := range idx.Search(tt.query, tt.k) {
+				ids = append(ids, m.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("Search(%v, %d) = %v, want %v", tt.query, tt.k, ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryIndexAdd(t *testing.T) {
+	idx := NewMemoryIndex()
+	idx.Add("a", []float32{1, 0})
+	idx.Add("b", []float32{0, 1})
+
+	// 같은 id 는 덮어씀
+	idx.Add("a", []float32{0, 1})
+	if idx.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", idx.Len())
+	}
+
+	matches := idx.Search([]float32{0, 1}, 0)
+	if len(matches) != 2 || math.Abs(matches[0].Score-1) > 1e-6 || math.Abs(matches[1].Score-1) > 1e-6 {
+		t.Errorf("Search = %+v, want a, b 모두 유사도 1", matches)
+	}
+
+	// 유사도가 같으면 추가한 순서 유지
+	if matches[0].ID != "a" || matches[1].ID != "b" {
+		t.Errorf("Search = %+v, want a, b 순서", matches)
+	}
+}